@@ -1,3 +1,5 @@
+//go:generate go run -C ../tools ./cmd/tagger-gen -tags my_json,my_logger ../examples
+
 package examples
 
 import (
@@ -46,7 +46,7 @@ func MyLoggerOut(data any, field *tagger.Field, in *reflect.Value) (any, error)
 				myLoggerOutData.LoggerMessage.Data = make(map[string]any)
 			}
 
-			myLoggerOutData.LoggerMessage.Data[field.Name()] = value
+			myLoggerOutData.LoggerMessage.Data[field.Key("my_logger")] = value
 		}
 
 		return data, nil
@@ -70,7 +70,9 @@ func main() {
 		Add(tagger.
 			New("my_logger").
 			OutFunction(MyLoggerOut).
-			Symbols(":", " | "),
+			Symbols(":", " | ").
+			Naming(tagger.SnakeCase).
+			Options(tagger.Opt("key").OneOf("summary", "data"), tagger.Opt("to").OneOf("string")),
 		)
 
 	req := MyDataForLogging{
@@ -0,0 +1,119 @@
+// Code generated by tagger-gen. DO NOT EDIT.
+
+package examples
+
+import (
+	"github.com/shindakioku/tagger"
+)
+
+func init() {
+	tagger.RegisterGenerated(taggerTypeMyDataForLogging, taggerInMyDataForLogging, taggerOutMyDataForLogging)
+	tagger.RegisterGenerated(taggerTypeProfile, taggerInProfile, taggerOutProfile)
+	tagger.RegisterGenerated(taggerTypeUser, taggerInUser, taggerOutUser)
+}
+
+var taggerTypeMyDataForLogging = tagger.NewGeneratedType((*MyDataForLogging)(nil))
+
+// taggerInMyDataForLogging fills fields of MyDataForLogging by the handlers of the tags
+func taggerInMyDataForLogging(s *tagger.GeneratedStruct, data any, value any) (err error) {
+	in := value.(*MyDataForLogging)
+	in.RequestData = new(RequestData)
+
+	if err = s.In(data, 0); err != nil {
+		return
+	}
+	if err = s.In(data, 1); err != nil {
+		return
+	}
+	if err = s.In(data, 2); err != nil {
+		return
+	}
+
+	return
+}
+
+// taggerOutMyDataForLogging exports fields of MyDataForLogging by the handlers of the tags
+func taggerOutMyDataForLogging(s *tagger.GeneratedStruct, data any, _ any) (output any, err error) {
+	output = data
+
+	if output, err = s.Out(output, 0); err != nil {
+		return
+	}
+	if output, err = s.Out(output, 1); err != nil {
+		return
+	}
+	if output, err = s.Out(output, 2); err != nil {
+		return
+	}
+
+	return
+}
+
+var taggerTypeProfile = tagger.NewGeneratedType((*Profile)(nil))
+
+// taggerInProfile fills fields of Profile by the handlers of the tags
+func taggerInProfile(s *tagger.GeneratedStruct, data any, value any) (err error) {
+	in := value.(*Profile)
+	in.Email = new(string)
+
+	if err = s.In(data, 0); err != nil {
+		return
+	}
+
+	return
+}
+
+// taggerOutProfile exports fields of Profile by the handlers of the tags
+func taggerOutProfile(s *tagger.GeneratedStruct, data any, _ any) (output any, err error) {
+	output = data
+
+	if output, err = s.Out(output, 0); err != nil {
+		return
+	}
+
+	return
+}
+
+var taggerTypeUser = tagger.NewGeneratedType((*User)(nil))
+
+// taggerInUser fills fields of User by the handlers of the tags
+func taggerInUser(s *tagger.GeneratedStruct, data any, value any) (err error) {
+	in := value.(*User)
+	in.Username = new(string)
+	in.Profile2 = new(Profile)
+
+	if err = s.In(data, 0); err != nil {
+		return
+	}
+	if err = s.In(data, 1); err != nil {
+		return
+	}
+	if err = s.In(data, 2); err != nil {
+		return
+	}
+	if err = s.In(data, 3); err != nil {
+		return
+	}
+
+	return
+}
+
+// taggerOutUser exports fields of User by the handlers of the tags
+func taggerOutUser(s *tagger.GeneratedStruct, data any, _ any) (output any, err error) {
+	output = data
+
+	if output, err = s.Out(output, 0); err != nil {
+		return
+	}
+	if output, err = s.Out(output, 1); err != nil {
+		return
+	}
+	if output, err = s.Out(output, 2); err != nil {
+		return
+	}
+	if output, err = s.Out(output, 3); err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,232 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ExplainOptions the same options as for In/Out
+type ExplainOptions struct {
+	// Tag for fields which not have tags
+	TagForEmpty string
+	// Tags for process. All defined tags if it's empty
+	Tags []string
+}
+
+// Explanation how a tagger sees a struct
+type Explanation struct {
+	Type   string           `json:"type"`
+	Fields []ExplainedField `json:"fields"`
+}
+
+// ExplainedField field of the struct with matched tags and handlers
+type ExplainedField struct {
+	// Names of the fields from the root struct: Profile.Email
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	Type string `json:"type"`
+	// Registered tags which are defined on the field
+	Tags []ExplainedTag `json:"tags,omitempty"`
+	// Names of the tags which handlers run for In
+	In []string `json:"in,omitempty"`
+	// Names of the tags which handlers run for Out
+	Out []string `json:"out,omitempty"`
+	// Fields of the nested struct
+	Fields []ExplainedField `json:"fields,omitempty"`
+}
+
+// ExplainedTag tag of the field parsed by symbols of the registered tag
+type ExplainedTag struct {
+	Name string `json:"name"`
+	// Key of the struct tags (the name or an alias of the tag)
+	Key string `json:"key"`
+	Raw string `json:"raw"`
+	// tuple (0 - key name, 1 - value)
+	Parsed [][2]string `json:"parsed,omitempty"`
+	// Validation error by declared options
+	Error string `json:"error,omitempty"`
+}
+
+// Explain returns how the tagger sees the struct (type can be a pointer to the struct)
+//
+//	explanation, err := tagger.Explain(reflect.TypeOf(User{}), ExplainOptions{TagForEmpty: "my_json"})
+//	fmt.Println(explanation)
+func (r *ReflectionTagger) Explain(typeOf reflect.Type, options ExplainOptions) (Explanation, error) {
+	return r.snapshot().explain(typeOf, options)
+}
+
+func (r ReflectionTagger) explain(typeOf reflect.Type, options ExplainOptions) (Explanation, error) {
+	explanation := Explanation{}
+	if typeOf == nil {
+		return explanation, errors.New("%type% cannot be empty")
+	}
+
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	if typeOf.Kind() != reflect.Struct {
+		return explanation, errors.New("%type% must be a structure")
+	}
+
+	tagsForWork, err := r.collectCorrectTags(options.Tags)
+	if err != nil {
+		return explanation, err
+	}
+
+	var handlerForEmptyField *Tag
+	if len(options.TagForEmpty) > 0 {
+		handlerForEmptyField, err = r.getHandlerForEmptyTag(options.TagForEmpty, tagsForWork)
+		if err != nil {
+			return explanation, err
+		}
+	}
+
+	explanation.Type = typeOf.String()
+	explanation.Fields = r.explainFields(typeOf, "", handlerForEmptyField, tagsForWork, map[reflect.Type]bool{typeOf: true})
+
+	return explanation, nil
+}
+
+func (r ReflectionTagger) explainFields(
+	typeOf reflect.Type,
+	path string,
+	handlerForEmptyField *Tag,
+	tagsForWork Tags,
+	parents map[reflect.Type]bool,
+) (fields []ExplainedField) {
+	for i := 0; i < typeOf.NumField(); i++ {
+		structField := typeOf.Field(i)
+		if !isProcessed(structField) {
+			continue
+		}
+
+		fieldTag := NewFieldTag(structField.Tag)
+		fieldTag.FieldName = structField.Name
+
+		explained := ExplainedField{
+			Path: path + structField.Name,
+			Kind: structField.Type.Kind().String(),
+			Type: structField.Type.String(),
+		}
+
+		for _, name := range r.ordered(r.tags) {
+			if explainedTag, exists := r.tags[name].Explain(structField.Tag, structField.Name); exists {
+				explained.Tags = append(explained.Tags, explainedTag)
+			}
+		}
+
+		handlers := r.makeHandlersForField(&Field{StructField: structField, Tag: fieldTag}, handlerForEmptyField, tagsForWork)
+		for _, name := range r.ordered(handlers) {
+			if handlers[name].SupportsIn() {
+				explained.In = append(explained.In, name)
+			}
+
+			if handlers[name].SupportsOut() {
+				explained.Out = append(explained.Out, name)
+			}
+		}
+
+		nestedType := structField.Type
+		if nestedType.Kind() == reflect.Ptr {
+			nestedType = nestedType.Elem()
+		}
+
+		// Recursive structs are explained only once
+		if nestedType.Kind() == reflect.Struct && !parents[nestedType] {
+			parents[nestedType] = true
+			explained.Fields = r.explainFields(nestedType, explained.Path+".", handlerForEmptyField, tagsForWork, parents)
+			delete(parents, nestedType)
+		}
+
+		fields = append(fields, explained)
+	}
+
+	return
+}
+
+// Explain returns how the tag sees the field: the field tag is parsed and validated the same way as for handlers.
+// Returns false if the tag isn't defined on the field.
+func (t *Tag) Explain(structTag reflect.StructTag, fieldName string) (ExplainedTag, bool) {
+	if _, exists := t.Lookup(structTag); !exists {
+		return ExplainedTag{}, false
+	}
+
+	fieldTag := NewFieldTag(structTag)
+	fieldTag.FieldName = fieldName
+	t.prepare(&fieldTag)
+
+	explained := ExplainedTag{Name: t.Name, Key: fieldTag.Name, Raw: fieldTag.Raw(), Parsed: fieldTag.ParsedTags}
+	if err := t.Validate(fieldTag); err != nil {
+		explained.Error = err.Error()
+	}
+
+	return explained, true
+}
+
+// WriteText writes the explanation as indented text
+//
+//	examples.User
+//	  ID uint (uint)
+//	    my_json "user_id" [user_id=user_id]
+//	    in: my_json
+//	    out: my_json
+func (e Explanation) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, e.Type); err != nil {
+		return err
+	}
+
+	return writeExplainedFields(w, e.Fields, 1)
+}
+
+func (e Explanation) String() string {
+	var builder strings.Builder
+	_ = e.WriteText(&builder)
+
+	return builder.String()
+}
+
+func writeExplainedFields(w io.Writer, fields []ExplainedField, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	for _, field := range fields {
+		lines := []string{fmt.Sprintf("%s%s %s (%s)", indent, field.Path, field.Type, field.Kind)}
+		for _, tag := range field.Tags {
+			parsed := make([]string, 0, len(tag.Parsed))
+			for _, v := range tag.Parsed {
+				parsed = append(parsed, v[0]+"="+v[1])
+			}
+
+			name := tag.Name
+			if len(tag.Key) != 0 && tag.Key != tag.Name {
+				name += " (" + tag.Key + ")"
+			}
+
+			line := fmt.Sprintf("%s  %s %q [%s]", indent, name, tag.Raw, strings.Join(parsed, ", "))
+			if len(tag.Error) != 0 {
+				line += " error: " + tag.Error
+			}
+			lines = append(lines, line)
+		}
+
+		if len(field.In) != 0 {
+			lines = append(lines, fmt.Sprintf("%s  in: %s", indent, strings.Join(field.In, ", ")))
+		}
+
+		if len(field.Out) != 0 {
+			lines = append(lines, fmt.Sprintf("%s  out: %s", indent, strings.Join(field.Out, ", ")))
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(lines, "\n")); err != nil {
+			return err
+		}
+
+		if err := writeExplainedFields(w, field.Fields, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
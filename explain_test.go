@@ -0,0 +1,133 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type explainTestProfile struct {
+	Email string `my_json:"email"`
+}
+
+type explainTestUser struct {
+	ID      int                 `my_json:"user_id" my_logger:"key:id | to:string"`
+	Profile *explainTestProfile `my_json:"profile"`
+	Comment string
+}
+
+func TestReflectionTagger_Explain(t *testing.T) {
+	cases := []struct {
+		name    string
+		typeOf  reflect.Type
+		options ExplainOptions
+		expect  func(explanation Explanation, err error) bool
+	}{
+		{
+			name:   "Test struct with nested struct",
+			typeOf: reflect.TypeOf(&explainTestUser{}),
+			expect: func(explanation Explanation, err error) bool {
+				return assert.Nil(t, err) && assert.Equal(t, "tagger.explainTestUser\n"+
+					"  ID int (int)\n"+
+					"    my_json \"user_id\" [user_id=user_id]\n"+
+					"    my_logger \"key:id | to:string\" [key=id, to=string] "+
+					"error: Incorrect value of the key: to (tag: my_logger, field: ID). Expected one of: int. Actual: string\n"+
+					"    in: my_json\n"+
+					"    out: my_json, my_logger\n"+
+					"  Profile *tagger.explainTestProfile (ptr)\n"+
+					"    my_json \"profile\" [profile=profile]\n"+
+					"    in: my_json\n"+
+					"    out: my_json\n"+
+					"    Profile.Email string (string)\n"+
+					"      my_json \"email\" [email=email]\n"+
+					"      in: my_json\n"+
+					"      out: my_json\n"+
+					"  Comment string (string)\n", explanation.String())
+			},
+		},
+		{
+			name:    "Test tag for empty and tags for process",
+			typeOf:  reflect.TypeOf(explainTestUser{}),
+			options: ExplainOptions{TagForEmpty: "my_logger", Tags: []string{"my_logger"}},
+			expect: func(explanation Explanation, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, []string{"my_logger"}, explanation.Fields[0].Out) &&
+					assert.Len(t, explanation.Fields[0].In, 0) &&
+					assert.Len(t, explanation.Fields[1].Out, 0) &&
+					assert.Equal(t, []string{"my_logger"}, explanation.Fields[2].Out)
+			},
+		},
+		{
+			name:   "Test not a struct",
+			typeOf: reflect.TypeOf(1),
+			expect: func(explanation Explanation, err error) bool {
+				return assert.Equal(t, errors.New("%type% must be a structure"), err)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		tag := NewReflectionTagger()
+		tag.
+			Add(New("my_json").
+				InFunction(func(data any, field *Field, in *reflect.Value) error { return nil }).
+				OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) { return data, nil }),
+			).
+			Add(New("my_logger").
+				Symbols(":", " | ").
+				Options(Opt("key"), Opt("to").OneOf("int")).
+				OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) { return data, nil }),
+			)
+
+		if !c.expect(tag.Explain(c.typeOf, c.options)) {
+			t.Error(fmt.Sprintf("[TestReflectionTagger_Explain] %s is not true", c.name))
+		}
+	}
+}
+
+func TestReflectionTagger_ExplainOrder(t *testing.T) {
+	tag := NewReflectionTagger()
+	tag.
+		Add(New("my_logger").
+			Symbols(":", " | ").
+			Options(Opt("key"), Opt("to").Default("string")).
+			OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) { return data, nil }),
+		).
+		Add(New("my_json").
+			OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) { return data, nil }),
+		)
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test tags and handlers in order of registration",
+			expect: func() bool {
+				explanation, err := tag.Explain(reflect.TypeOf(explainTestUser{}), ExplainOptions{})
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, "my_logger", explanation.Fields[0].Tags[0].Name) &&
+					assert.Equal(t, "my_json", explanation.Fields[0].Tags[1].Name) &&
+					assert.Equal(t, []string{"my_logger", "my_json"}, explanation.Fields[0].Out)
+			},
+		},
+		{
+			name: "Test field tag is prepared as for handlers",
+			expect: func() bool {
+				explanation, err := tag.Explain(reflect.TypeOf(explainTestUser{}), ExplainOptions{})
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, [][2]string{{"key", "id"}, {"to", "string"}}, explanation.Fields[0].Tags[0].Parsed)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTagger_ExplainOrder] %s is not true", c.name))
+		}
+	}
+}
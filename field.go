@@ -23,6 +23,13 @@ type Field struct {
 
 	// For call
 	tags Tags
+	// Options of the struct which contains the field: for all tags and for the processed tag
+	structTag     reflect.StructTag
+	structOptions FieldTag
+	// Naming strategy of the tagger
+	naming NamingStrategy
+	// TaggerUnmarshaler/TaggerMarshaler of the field type handled the value, so fields of the nested struct aren't processed
+	marshaled bool
 }
 
 func (f Field) Get() any {
@@ -41,6 +48,15 @@ func (f Field) Name() string {
 	return f.StructField.Name
 }
 
+// Path names of the fields from the root struct: Profile.Email
+func (f Field) Path() string {
+	if f.ParentStruct == nil || f.ParentStruct.ParentField == nil {
+		return f.Name()
+	}
+
+	return f.ParentStruct.ParentField.Path() + "." + f.Name()
+}
+
 func (f Field) Type() reflect.Kind {
 	return f.StructField.Type.Kind()
 }
@@ -9,47 +9,116 @@ import (
 type FieldTag struct {
 	// reflection.Tag
 	StructTag reflect.StructTag
-	// Tag - data by the handler name (every handler must have unique name)
-	//StructTagByKey string
+	// Name of the tag which processing the field (every handler must have unique name).
+	// If it's defined then only data by the name is parsed: `my_json:"data"` -> data
+	Name string
+	// Name of the struct field which holds the tag
+	FieldName string
 	// Your defined symbols
 	TagSymbols TagSymbols
+	// Keys are compared as is, otherwise they are lower-cased
+	CaseSensitive bool
+	// Keys which values in square brackets are lists: "alias:[a, b]" (options declared as Multiple).
+	// Values of other keys are kept as is: "pattern:[a-z]"
+	ListKeys []string
 
 	// Parsed tags by keys
 	// tuple (0 - key name, 1 - value)
 	ParsedTags [][2]string
+	// Keys of the ParsedTags as they are written in the tag (same indexes as ParsedTags)
+	OriginalKeys []string
 }
 
 // Values split field tags by key separator
 // "foo | bar | baz" - key separator is '|'
+// Separator inside of quotes or escaped by backslash is ignored: "'foo|bar' | baz"
 func (t FieldTag) Values() []string {
-	return strings.Split(t.ToString(), t.TagSymbols.KeysSeparator)
+	parts := splitTag(t.Raw(), t.TagSymbols)
+	for i, part := range parts {
+		parts[i] = unquoteTag(part, t.TagSymbols)
+	}
+
+	return parts
+}
+
+// Parts split field tags by key separator without removing quotes and escapes
+// "'foo|bar' | baz" -> ['foo|bar', baz]
+func (t FieldTag) Parts() []string {
+	return splitTag(t.Raw(), t.TagSymbols)
+}
+
+// Check returns an error if the field tags are malformed by the symbols
+// (unterminated quote or list, repeated separator, empty key)
+func (t FieldTag) Check() error {
+	return checkTag(t.Raw(), t.TagSymbols, t.isListKey)
 }
 
 // TagsToParsed
 // returns tuple (0 - key name, 1 - value)
-func (t FieldTag) TagsToParsed() (output [][2]string) {
-	for _, value := range t.Values() {
-		exploded := strings.Split(value, t.TagSymbols.KeyValue)
-		if len(exploded) == 0 {
-			continue
-		}
+func (t FieldTag) TagsToParsed() [][2]string {
+	output, _ := t.parse()
+
+	return output
+}
+
+// Parse fills ParsedTags and OriginalKeys
+func (t *FieldTag) Parse() {
+	t.ParsedTags, t.OriginalKeys = t.parse()
+}
 
-		if len(exploded) == 1 {
-			if len(value) == 0 {
+func (t FieldTag) parse() (output [][2]string, keys []string) {
+	for _, part := range splitTag(t.Raw(), t.TagSymbols) {
+		key, value, ok := splitKeyValue(part, t.TagSymbols)
+		if !ok {
+			if len(part) == 0 {
 				continue
 			}
 
-			output = append(output, [2]string{strings.ToLower(value), exploded[0]})
+			value = unquoteTag(part, t.TagSymbols)
+			output = append(output, [2]string{t.normalizeKey(value), value})
+			keys = append(keys, value)
 
 			continue
 		}
 
-		output = append(output, [2]string{strings.ToLower(exploded[0]), exploded[1]})
+		key = unquoteTag(key, t.TagSymbols)
+		values, isList := []string(nil), false
+		if t.isListKey(key) {
+			values, isList = splitList(value)
+		}
+		if !isList {
+			values = []string{unquoteTag(value, t.TagSymbols)}
+		}
+
+		for _, v := range values {
+			output = append(output, [2]string{t.normalizeKey(key), v})
+			keys = append(keys, key)
+		}
 	}
 
 	return
 }
 
+// isListKey is the key declared as a list
+func (t FieldTag) isListKey(key string) bool {
+	key = t.normalizeKey(key)
+	for _, listKey := range t.ListKeys {
+		if t.normalizeKey(listKey) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t FieldTag) normalizeKey(key string) string {
+	if t.CaseSensitive {
+		return key
+	}
+
+	return strings.ToLower(key)
+}
+
 // IsEmpty does field contains any tags
 func (t FieldTag) IsEmpty() bool {
 	return len(t.StructTag) == 0
@@ -59,9 +128,20 @@ func (t FieldTag) ToString() string {
 	return string(t.StructTag)
 }
 
+// Raw returns data of the tag by the Name or whole tag if Name isn't defined
+//
+//	`my_json:"a:b"` -> a:b
+func (t FieldTag) Raw() string {
+	if len(t.Name) == 0 {
+		return t.ToString()
+	}
+
+	return t.StructTag.Get(t.Name)
+}
+
 // Exists returns true if key defined in the field tags
 func (t FieldTag) Exists(key string) bool {
-	key = strings.ToLower(key)
+	key = t.normalizeKey(key)
 	for _, v := range t.ParsedTags {
 		if v[0] == key {
 			return true
@@ -73,7 +153,7 @@ func (t FieldTag) Exists(key string) bool {
 
 // FindIndexByKey returns index and exists (bool) if key defined in the fields tags
 func (t FieldTag) FindIndexByKey(key string) (int, bool) {
-	key = strings.ToLower(key)
+	key = t.normalizeKey(key)
 	for i, v := range t.ParsedTags {
 		if v[0] == key {
 			return i, true
@@ -85,7 +165,7 @@ func (t FieldTag) FindIndexByKey(key string) (int, bool) {
 
 // FindByKey returns value and exists (bool) if key defined in the fields tags
 func (t FieldTag) FindByKey(key string) (string, bool) {
-	key = strings.ToLower(key)
+	key = t.normalizeKey(key)
 	for _, v := range t.ParsedTags {
 		if v[0] == key {
 			return v[1], true
@@ -95,6 +175,40 @@ func (t FieldTag) FindByKey(key string) (string, bool) {
 	return "", false
 }
 
+// OriginalKey returns key as it's written in the tag
+func (t FieldTag) OriginalKey(key string) (string, bool) {
+	index, exists := t.FindIndexByKey(key)
+	if !exists || index >= len(t.OriginalKeys) {
+		return "", false
+	}
+
+	return t.OriginalKeys[index], true
+}
+
+// FindAllByKey returns all values of the key: "alias:a | alias:b" or "alias:[a, b]"
+func (t FieldTag) FindAllByKey(key string) (values []string) {
+	key = t.normalizeKey(key)
+	for _, v := range t.ParsedTags {
+		if v[0] == key {
+			values = append(values, v[1])
+		}
+	}
+
+	return
+}
+
+// Map returns values of the field tags by keys
+//
+//	"alias:[a, b] | key:value" -> map[alias:[a b] key:[value]]
+func (t FieldTag) Map() map[string][]string {
+	output := make(map[string][]string, len(t.ParsedTags))
+	for _, v := range t.ParsedTags {
+		output[v[0]] = append(output[v[0]], v[1])
+	}
+
+	return output
+}
+
 func NewFieldTag(tag reflect.StructTag) FieldTag {
 	return FieldTag{
 		StructTag: tag,
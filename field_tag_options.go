@@ -0,0 +1,154 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Typed accessors for values of the tag keys.
+// Every accessor returns an error which describes the field, the tag and the key.
+//
+//	// my_tag:"limit:10 | timeout:1s | omitempty"
+//	limit, err := field.Tag.Int("limit")
+//	timeout, err := field.Tag.Duration("timeout")
+//	omitEmpty, err := field.Tag.Bool("omitempty")
+
+// Int returns value of the key as int
+func (t FieldTag) Int(key string) (int, error) {
+	value, err := t.mustFindByKey(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.toInt(key, value)
+}
+
+// Bool returns value of the key as bool.
+// Bare key (flag) is true: "omitempty". Missing key is false.
+func (t FieldTag) Bool(key string) (bool, error) {
+	value, exists := t.FindByKey(key)
+	if !exists {
+		return false, nil
+	}
+
+	return t.toBool(key, value)
+}
+
+// Duration returns value of the key as time.Duration: "timeout:1m30s"
+func (t FieldTag) Duration(key string) (time.Duration, error) {
+	value, err := t.mustFindByKey(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.toDuration(key, value)
+}
+
+// StringSlice returns value of the key split by separator: "fields:a,b,c" -> [a b c]
+func (t FieldTag) StringSlice(key, separator string) ([]string, error) {
+	value, err := t.mustFindByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	values := strings.Split(value, separator)
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+
+	return values, nil
+}
+
+// Enum returns value of the key if it's one of the allowed values: "to:string"
+func (t FieldTag) Enum(key string, allowed ...string) (string, error) {
+	value, err := t.mustFindByKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return t.toEnum(key, value, allowed)
+}
+
+// Regexp returns compiled value of the key: "pattern:'^[a-z]+$'"
+func (t FieldTag) Regexp(key string) (*regexp.Regexp, error) {
+	value, err := t.mustFindByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.toRegexp(key, value)
+}
+
+func (t FieldTag) toInt(key, value string) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, t.optionError(key, fmt.Sprintf("Expected integer. Actual: %s", value))
+	}
+
+	return number, nil
+}
+
+func (t FieldTag) toBool(key, value string) (bool, error) {
+	// Flag: the value is the key itself
+	if t.normalizeKey(key) == t.normalizeKey(value) {
+		return true, nil
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, t.optionError(key, fmt.Sprintf("Expected boolean. Actual: %s", value))
+	}
+
+	return flag, nil
+}
+
+func (t FieldTag) toDuration(key, value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, t.optionError(key, fmt.Sprintf("Expected duration. Actual: %s", value))
+	}
+
+	return duration, nil
+}
+
+func (t FieldTag) toEnum(key, value string, allowed []string) (string, error) {
+	if !ContainsInSlice(allowed, value) {
+		return "", t.optionError(key, fmt.Sprintf(
+			"Expected one of: %s. Actual: %s", strings.Join(allowed, ", "), value,
+		))
+	}
+
+	return value, nil
+}
+
+func (t FieldTag) toRegexp(key, value string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile(value)
+	if err != nil {
+		return nil, t.optionError(key, fmt.Sprintf("Expected regular expression. %s", err))
+	}
+
+	return compiled, nil
+}
+
+func (t FieldTag) mustFindByKey(key string) (string, error) {
+	value, exists := t.FindByKey(key)
+	if !exists {
+		return "", t.optionError(key, "Key doesn't exists")
+	}
+
+	return value, nil
+}
+
+func (t FieldTag) optionError(key, message string) error {
+	return errors.New(fmt.Sprintf(
+		"Incorrect value of the key: %s (tag: %s, field: %s). %s",
+		key,
+		t.Name,
+		t.FieldName,
+		message,
+	))
+}
@@ -0,0 +1,109 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestFieldTag_TypedAccessors(t *testing.T) {
+	cases := []struct {
+		name   string
+		tags   string
+		expect func(fieldTag FieldTag) bool
+	}{
+		{
+			name: "Test int",
+			tags: "limit:10;offset:foo",
+			expect: func(fieldTag FieldTag) bool {
+				limit, err := fieldTag.Int("limit")
+				_, offsetErr := fieldTag.Int("offset")
+				_, missingErr := fieldTag.Int("page")
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, 10, limit) &&
+					assert.Equal(t, errors.New(
+						"Incorrect value of the key: offset (tag: my_tag, field: ID). Expected integer. Actual: foo",
+					), offsetErr) &&
+					assert.Equal(t, errors.New(
+						"Incorrect value of the key: page (tag: my_tag, field: ID). Key doesn't exists",
+					), missingErr)
+			},
+		},
+		{
+			name: "Test bool",
+			tags: "omitempty;inline:false;strict:yes",
+			expect: func(fieldTag FieldTag) bool {
+				omitEmpty, omitEmptyErr := fieldTag.Bool("omitempty")
+				inline, inlineErr := fieldTag.Bool("inline")
+				missing, missingErr := fieldTag.Bool("missing")
+				_, strictErr := fieldTag.Bool("strict")
+
+				return assert.Nil(t, omitEmptyErr) && assert.True(t, omitEmpty) &&
+					assert.Nil(t, inlineErr) && assert.False(t, inline) &&
+					assert.Nil(t, missingErr) && assert.False(t, missing) &&
+					assert.NotNil(t, strictErr)
+			},
+		},
+		{
+			name: "Test duration",
+			tags: "timeout:1m30s",
+			expect: func(fieldTag FieldTag) bool {
+				timeout, err := fieldTag.Duration("timeout")
+
+				return assert.Nil(t, err) && assert.Equal(t, 90*time.Second, timeout)
+			},
+		},
+		{
+			name: "Test string slice",
+			tags: "fields:a, b,c",
+			expect: func(fieldTag FieldTag) bool {
+				fields, err := fieldTag.StringSlice("fields", ",")
+
+				return assert.Nil(t, err) && assert.Equal(t, []string{"a", "b", "c"}, fields)
+			},
+		},
+		{
+			name: "Test enum",
+			tags: "to:string;from:bytes",
+			expect: func(fieldTag FieldTag) bool {
+				to, err := fieldTag.Enum("to", "string", "int")
+				_, fromErr := fieldTag.Enum("from", "string", "int")
+
+				return assert.Nil(t, err) && assert.Equal(t, "string", to) &&
+					assert.Equal(t, errors.New(
+						"Incorrect value of the key: from (tag: my_tag, field: ID). Expected one of: string, int. Actual: bytes",
+					), fromErr)
+			},
+		},
+		{
+			name: "Test regexp",
+			tags: "pattern:'^a|b$';invalid:'('",
+			expect: func(fieldTag FieldTag) bool {
+				pattern, err := fieldTag.Regexp("pattern")
+				_, invalidErr := fieldTag.Regexp("invalid")
+
+				return assert.Nil(t, err) && assert.Equal(t, regexp.MustCompile("^a|b$"), pattern) &&
+					assert.NotNil(t, invalidErr)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fieldTag := FieldTag{
+			StructTag:  reflect.StructTag(fmt.Sprintf(`my_tag:"%s"`, c.tags)),
+			Name:       "my_tag",
+			FieldName:  "ID",
+			TagSymbols: TagSymbols{":", ";"},
+		}
+		fieldTag.Parse()
+
+		if !c.expect(fieldTag) {
+			t.Error(fmt.Sprintf("[TestFieldTag_TypedAccessors] %s is not true", c.name))
+		}
+	}
+}
@@ -67,6 +67,60 @@ func TestFieldTag_TagsToParsed(t *testing.T) {
 					assert.Equal(t, [][2]string{{"key", "value"}, {"key2", "value2"}}, values)
 			},
 		},
+		{
+			name:    "Test value with key value symbol",
+			tags:    "default:a:b;key:value",
+			symbols: TagSymbols{":", ";"},
+			expect: func(values [][2]string) bool {
+				return assert.Len(t, values, 2) &&
+					assert.Equal(t, [][2]string{{"default", "a:b"}, {"key", "value"}}, values)
+			},
+		},
+		{
+			name:    "Test quoted values",
+			tags:    `regexp:'^a|b$'|default:"a:b"`,
+			symbols: TagSymbols{":", "|"},
+			expect: func(values [][2]string) bool {
+				return assert.Len(t, values, 2) &&
+					assert.Equal(t, [][2]string{{"regexp", "^a|b$"}, {"default", "a:b"}}, values)
+			},
+		},
+		{
+			name:    "Test escaped separators",
+			tags:    `regexp:^a\|b$|key\:name:value`,
+			symbols: TagSymbols{":", "|"},
+			expect: func(values [][2]string) bool {
+				return assert.Len(t, values, 2) &&
+					assert.Equal(t, [][2]string{{"regexp", "^a|b$"}, {"key:name", "value"}}, values)
+			},
+		},
+		{
+			name:    "Test quote inside of a value",
+			tags:    `default:it's;key:"it's"`,
+			symbols: TagSymbols{":", ";"},
+			expect: func(values [][2]string) bool {
+				return assert.Len(t, values, 2) &&
+					assert.Equal(t, [][2]string{{"default", "it's"}, {"key", "it's"}}, values)
+			},
+		},
+		{
+			name:    "Test backslashes which don't escape symbols",
+			tags:    `pattern:^\d+\.\w$;path:C:\temp;key:a\;b`,
+			symbols: TagSymbols{":", ";"},
+			expect: func(values [][2]string) bool {
+				return assert.Len(t, values, 3) &&
+					assert.Equal(t, [][2]string{{"pattern", `^\d+\.\w$`}, {"path", `C:\temp`}, {"key", "a;b"}}, values)
+			},
+		},
+		{
+			name:    "Test multi symbols separator",
+			tags:    "key:summary | to:'a | b'",
+			symbols: TagSymbols{":", " | "},
+			expect: func(values [][2]string) bool {
+				return assert.Len(t, values, 2) &&
+					assert.Equal(t, [][2]string{{"key", "summary"}, {"to", "a | b"}}, values)
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -81,6 +135,44 @@ func TestFieldTag_TagsToParsed(t *testing.T) {
 	}
 }
 
+func TestFieldTag_Values(t *testing.T) {
+	cases := []struct {
+		name     string
+		tag      reflect.StructTag
+		tagName  string
+		symbols  TagSymbols
+		expected []string
+	}{
+		{
+			name:     "Test whole tag without name",
+			tag:      "a;b",
+			symbols:  TagSymbols{":", ";"},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "Test data by the name",
+			tag:      `my_json:"email;'a;b'" db:"email"`,
+			tagName:  "my_json",
+			symbols:  TagSymbols{":", ";"},
+			expected: []string{"email", "a;b"},
+		},
+		{
+			name:     "Test empty separator",
+			tag:      `my_json:"a;b"`,
+			tagName:  "my_json",
+			expected: []string{"a;b"},
+		},
+	}
+
+	for _, c := range cases {
+		fieldTag := FieldTag{StructTag: c.tag, Name: c.tagName, TagSymbols: c.symbols}
+
+		if !assert.Equal(t, c.expected, fieldTag.Values()) {
+			t.Error(fmt.Sprintf("[TestFieldTag_Values] %s is not true", c.name))
+		}
+	}
+}
+
 func TestFieldTag_Exists(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -217,3 +309,136 @@ func TestFieldTag_FindByKey(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldTag_CaseSensitive(t *testing.T) {
+	cases := []struct {
+		name          string
+		tags          string
+		caseSensitive bool
+		key           string
+		expect        func(fieldTag FieldTag, value string, exists bool) bool
+	}{
+		{
+			name: "Keys are lower-cased by default",
+			tags: "X-Request-ID:id",
+			key:  "x-request-id",
+			expect: func(fieldTag FieldTag, value string, exists bool) bool {
+				original, _ := fieldTag.OriginalKey("X-REQUEST-ID")
+
+				return assert.True(t, exists) &&
+					assert.Equal(t, "id", value) &&
+					assert.Equal(t, [][2]string{{"x-request-id", "id"}}, fieldTag.ParsedTags) &&
+					assert.Equal(t, "X-Request-ID", original)
+			},
+		},
+		{
+			name:          "Keys are kept as is",
+			tags:          "X-Request-ID:id;HOME",
+			caseSensitive: true,
+			key:           "X-Request-ID",
+			expect: func(fieldTag FieldTag, value string, exists bool) bool {
+				return assert.True(t, exists) &&
+					assert.Equal(t, "id", value) &&
+					assert.Equal(t, [][2]string{{"X-Request-ID", "id"}, {"HOME", "HOME"}}, fieldTag.ParsedTags) &&
+					assert.False(t, fieldTag.Exists("home"))
+			},
+		},
+		{
+			name:          "Key with another case must not exists",
+			tags:          "X-Request-ID:id",
+			caseSensitive: true,
+			key:           "x-request-id",
+			expect: func(fieldTag FieldTag, value string, exists bool) bool {
+				return assert.False(t, exists) &&
+					assert.Len(t, value, 0)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fieldTag := FieldTag{
+			StructTag:     reflect.StructTag(c.tags),
+			TagSymbols:    TagSymbols{":", ";"},
+			CaseSensitive: c.caseSensitive,
+		}
+		fieldTag.Parse()
+
+		value, exists := fieldTag.FindByKey(c.key)
+		if !c.expect(fieldTag, value, exists) {
+			t.Error(fmt.Sprintf("[TestFieldTag_CaseSensitive] %s is not true", c.name))
+		}
+	}
+}
+
+func TestFieldTag_FindAllByKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		tags   string
+		expect func(fieldTag FieldTag) bool
+	}{
+		{
+			name: "Must not exists",
+			tags: "key:value",
+			expect: func(fieldTag FieldTag) bool {
+				return assert.Len(t, fieldTag.FindAllByKey("alias"), 0)
+			},
+		},
+		{
+			name: "Test repeated keys",
+			tags: "alias:a;key:value;ALIAS:b",
+			expect: func(fieldTag FieldTag) bool {
+				return assert.Equal(t, []string{"a", "b"}, fieldTag.FindAllByKey("alias")) &&
+					assert.Equal(t, map[string][]string{"alias": {"a", "b"}, "key": {"value"}}, fieldTag.Map())
+			},
+		},
+		{
+			name: "Test list syntax",
+			tags: "alias:[a, 'b,c', d\\,e];key:value",
+			expect: func(fieldTag FieldTag) bool {
+				value, _ := fieldTag.FindByKey("alias")
+
+				return assert.Equal(t, []string{"a", "b,c", "d,e"}, fieldTag.FindAllByKey("alias")) &&
+					assert.Equal(t, "a", value) &&
+					assert.Equal(t, []string{"alias", "alias", "alias", "key"}, fieldTag.OriginalKeys)
+			},
+		},
+		{
+			name: "Test empty list and quoted brackets",
+			tags: "alias:[];pattern:'[a-z]'",
+			expect: func(fieldTag FieldTag) bool {
+				return assert.False(t, fieldTag.Exists("alias")) &&
+					assert.Equal(t, []string{"[a-z]"}, fieldTag.FindAllByKey("pattern"))
+			},
+		},
+		{
+			name: "Test brackets of keys which aren't lists",
+			tags: "pattern:[a-z];default:[a, b];ALIAS:[c]",
+			expect: func(fieldTag FieldTag) bool {
+				return assert.Equal(t, []string{"[a-z]"}, fieldTag.FindAllByKey("pattern")) &&
+					assert.Equal(t, []string{"[a, b]"}, fieldTag.FindAllByKey("default")) &&
+					assert.Equal(t, []string{"c"}, fieldTag.FindAllByKey("alias")) &&
+					assert.Nil(t, fieldTag.Check())
+			},
+		},
+		{
+			name: "Test unterminated list",
+			tags: "alias:[a, b",
+			expect: func(fieldTag FieldTag) bool {
+				return assert.EqualError(t, fieldTag.Check(), `Unterminated list in "alias:[a, b"`)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fieldTag := FieldTag{
+			StructTag:  reflect.StructTag(c.tags),
+			TagSymbols: TagSymbols{":", ";"},
+			ListKeys:   []string{"alias"},
+		}
+		fieldTag.Parse()
+
+		if !c.expect(fieldTag) {
+			t.Error(fmt.Sprintf("[TestFieldTag_FindAllByKey] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package tagger
+
+import (
+	"reflect"
+	"strings"
+)
+
+// GeneratedIn fills fields of the struct by the handlers of the tags. value is a pointer to the struct.
+// Functions are generated by tools/cmd/tagger-gen.
+type GeneratedIn func(s *GeneratedStruct, data any, value any) error
+
+// GeneratedOut exports fields of the struct by the handlers of the tags. value is a pointer to the struct.
+// Functions are generated by tools/cmd/tagger-gen.
+type GeneratedOut func(s *GeneratedStruct, data any, value any) (any, error)
+
+// GeneratedType described struct for the generated code.
+// Struct fields are collected only once (at registration), field tags are parsed once by every tagger.
+type GeneratedType struct {
+	Type   reflect.Type
+	fields []Field
+	// Types of the fields implement TaggerUnmarshaler/TaggerMarshaler (by index of the field)
+	unmarshalers []bool
+	marshalers   []bool
+
+	In  GeneratedIn
+	Out GeneratedOut
+}
+
+// generated types by struct type (filled by init functions of the generated code)
+var generated = make(map[reflect.Type]*GeneratedType)
+
+var (
+	unmarshalerType = reflect.TypeOf((*TaggerUnmarshaler)(nil)).Elem()
+	marshalerType   = reflect.TypeOf((*TaggerMarshaler)(nil)).Elem()
+)
+
+// NewGeneratedType initialize of a type for the generated code
+//
+//	var taggerTypeUser = tagger.NewGeneratedType((*User)(nil))
+func NewGeneratedType(value any) *GeneratedType {
+	typeOf := reflect.TypeOf(value)
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	generatedType := &GeneratedType{Type: typeOf}
+	for i := 0; i < typeOf.NumField(); i++ {
+		structField := typeOf.Field(i)
+		fieldTag := NewFieldTag(structField.Tag)
+		fieldTag.FieldName = structField.Name
+
+		kind := structField.Type.Kind()
+		generatedType.fields = append(generatedType.fields, Field{
+			StructField: structField,
+			Index:       i,
+			IsStruct:    kind == reflect.Struct || (kind == reflect.Ptr && structField.Type.Elem().Kind() == reflect.Struct),
+			Tag:         fieldTag,
+		})
+		generatedType.unmarshalers = append(generatedType.unmarshalers, implements(structField.Type, unmarshalerType))
+		generatedType.marshalers = append(generatedType.marshalers, implements(structField.Type, marshalerType))
+	}
+
+	return generatedType
+}
+
+// implements does the type or the pointer to it implement the interface
+func implements(typeOf, interfaceType reflect.Type) bool {
+	return typeOf.Implements(interfaceType) || reflect.PtrTo(typeOf).Implements(interfaceType)
+}
+
+// RegisterGenerated registers generated functions of the type.
+// Every tagger uses them instead of reflection for the type. Must be called only from init functions.
+//
+//	func init() {
+//	  tagger.RegisterGenerated(taggerTypeUser, taggerInUser, taggerOutUser)
+//	}
+func RegisterGenerated(generatedType *GeneratedType, in GeneratedIn, out GeneratedOut) {
+	generatedType.In = in
+	generatedType.Out = out
+	generated[generatedType.Type] = generatedType
+}
+
+// findGenerated returns generated type if it's registered and the value can be passed to it
+func findGenerated(valueOf reflect.Value) (*GeneratedType, bool) {
+	if !valueOf.CanAddr() {
+		return nil, false
+	}
+
+	generatedType, exists := generated[valueOf.Type()]
+
+	return generatedType, exists
+}
+
+// preparedKey generated type with the arguments of In/Out
+type preparedKey struct {
+	typeOf      reflect.Type
+	tagForEmpty string
+	tags        string
+	// options of the struct (options can be registered after the first In/Out)
+	structTag reflect.StructTag
+}
+
+// preparedField field of a generated type with its handlers
+type preparedField struct {
+	field    Field
+	handlers []fieldHandler
+}
+
+// GeneratedStruct processing of a struct by the generated functions:
+// fields are passed to the handlers of the tags with the field tags parsed only once (values of the fields are
+// still taken by reflection, handlers get them as reflect.Value)
+type GeneratedStruct struct {
+	tagger        ReflectionTagger
+	structCall    structCall
+	generatedType *GeneratedType
+	fields        []preparedField
+	// handlers are called without middlewares
+	direct bool
+}
+
+// generatedStruct returns processing of the struct with the prepared fields of the type
+func (r ReflectionTagger) generatedStruct(generatedType *GeneratedType, structCall structCall, tagsForWork Tags) (*GeneratedStruct, error) {
+	key := preparedKey{
+		typeOf:      generatedType.Type,
+		tagForEmpty: structCall.tagForEmpty,
+		structTag:   structOptionsOf(generatedType.Type),
+	}
+	if len(structCall.tags) != 0 {
+		key.tags = strings.Join(structCall.tags, ",")
+	}
+
+	fields, exists := r.prepared.Load(key)
+	if !exists {
+		prepared, err := r.prepareFields(generatedType, key.structTag, structCall.tagForEmpty, tagsForWork)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, _ = r.prepared.LoadOrStore(key, prepared)
+	}
+
+	return &GeneratedStruct{
+		tagger:        r,
+		structCall:    structCall,
+		generatedType: generatedType,
+		fields:        fields.([]preparedField),
+		direct:        len(r.middlewares) == 0 && !r.recover,
+	}, nil
+}
+
+// prepareFields finds handlers of the fields and parses the field tags for them
+func (r ReflectionTagger) prepareFields(
+	generatedType *GeneratedType, structTag reflect.StructTag, tagForEmpty string, tagsForWork Tags,
+) ([]preparedField, error) {
+	var (
+		handlerForEmptyField *Tag
+		err                  error
+	)
+	if len(tagForEmpty) > 0 {
+		handlerForEmptyField, err = r.getHandlerForEmptyTag(tagForEmpty, tagsForWork)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]preparedField, len(generatedType.fields))
+	for i := range generatedType.fields {
+		field := generatedType.fields[i]
+		field.structTag = structTag
+		field.tags = r.makeHandlersForField(&field, handlerForEmptyField, tagsForWork)
+		fields[i] = preparedField{field: field, handlers: r.fieldHandlers(&field)}
+	}
+
+	return fields, nil
+}
+
+// field returns the field by index with its value
+func (s *GeneratedStruct) field(index int) *Field {
+	field := s.fields[index].field
+	field.Value = s.structCall.valueOf.Field(index)
+	// Nested struct without pointer is passed by pointer (as by reflection)
+	if field.Value.Kind() == reflect.Struct {
+		field.Value = field.Value.Addr()
+	}
+	field.ParentStruct = s.structCall.parentStruct
+	field.naming = s.tagger.naming
+
+	return &field
+}
+
+// Allocate sets a new value to the pointer field by index (for types which can't be named by the generated code)
+func (s *GeneratedStruct) Allocate(index int) {
+	value := s.structCall.valueOf.Field(index)
+	value.Set(reflect.New(value.Type().Elem()))
+}
+
+// In calls In handlers of the field by index and fills the nested struct
+func (s *GeneratedStruct) In(data any, index int) error {
+	prepared := &s.fields[index]
+	if len(prepared.handlers) == 0 && !prepared.field.IsStruct {
+		return nil
+	}
+
+	direct := s.direct && !s.generatedType.unmarshalers[index]
+
+	return s.tagger.inField(s.structCall, data, s.field(index), prepared.handlers, direct)
+}
+
+// Out calls Out handlers of the field by index and exports the nested struct
+func (s *GeneratedStruct) Out(data any, index int) (any, error) {
+	prepared := &s.fields[index]
+	if len(prepared.handlers) == 0 && !prepared.field.IsStruct {
+		return data, nil
+	}
+
+	direct := s.direct && !s.generatedType.marshalers[index]
+
+	return s.tagger.outField(s.structCall, data, s.field(index), prepared.handlers, direct)
+}
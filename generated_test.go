@@ -0,0 +1,253 @@
+package tagger
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type generatedTestProfile struct {
+	Email string `my_tag:"email"`
+}
+
+type generatedTestUser struct {
+	ID       int                   `my_tag:"id"`
+	Profile  generatedTestProfile  `my_tag:"profile"`
+	Profile2 *generatedTestProfile `my_tag:"profile2"`
+}
+
+// The same struct without the generated code
+type reflectionTestUser generatedTestUser
+
+// The same code as generated by tagger-gen
+func init() {
+	RegisterGenerated(generatedTypeUser, generatedInUser, generatedOutUser)
+}
+
+var generatedTypeUser = NewGeneratedType((*generatedTestUser)(nil))
+
+// generated functions were called
+var generatedCalls int
+
+func generatedInUser(s *GeneratedStruct, data any, value any) (err error) {
+	in := value.(*generatedTestUser)
+	in.Profile2 = new(generatedTestProfile)
+	generatedCalls++
+
+	if err = s.In(data, 0); err != nil {
+		return
+	}
+	if err = s.In(data, 1); err != nil {
+		return
+	}
+	if err = s.In(data, 2); err != nil {
+		return
+	}
+
+	return
+}
+
+func generatedOutUser(s *GeneratedStruct, data any, _ any) (output any, err error) {
+	output = data
+	generatedCalls++
+
+	if output, err = s.Out(output, 0); err != nil {
+		return
+	}
+	if output, err = s.Out(output, 1); err != nil {
+		return
+	}
+	if output, err = s.Out(output, 2); err != nil {
+		return
+	}
+
+	return
+}
+
+func TestRegisterGenerated(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  any
+		expect func(value any, keys []string, err error) bool
+	}{
+		{
+			name:  "Test generated struct with nested structs",
+			value: &generatedTestUser{},
+			expect: func(value any, keys []string, err error) bool {
+				user := value.(*generatedTestUser)
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, 1, user.ID) &&
+					assert.Equal(t, "email", user.Profile.Email) &&
+					assert.Equal(t, "email", user.Profile2.Email) &&
+					assert.Equal(t, []string{"id", "profile", "email", "profile2", "email"}, keys)
+			},
+		},
+		{
+			name:  "Test pointer to pointer",
+			value: func() any { user := &generatedTestUser{}; return &user }(),
+			expect: func(value any, keys []string, err error) bool {
+				user := *value.(**generatedTestUser)
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, 1, user.ID) &&
+					assert.Equal(t, "email", user.Profile2.Email) &&
+					assert.Len(t, keys, 5)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		var keys []string
+		tag := NewReflectionTagger().Add(New("my_tag").InFunction(func(data any, field *Field, in *reflect.Value) error {
+			keys = append(keys, field.Tag.Raw())
+			switch field.Type() {
+			case reflect.Int:
+				return field.Set(1)
+			case reflect.String:
+				return field.Set(field.Tag.Raw())
+			}
+
+			return nil
+		}))
+
+		err := tag.In(nil, c.value, "")
+		if !c.expect(c.value, keys, err) {
+			t.Error(fmt.Sprintf("[TestRegisterGenerated] %s is not true", c.name))
+		}
+	}
+}
+
+func TestGeneratedStruct(t *testing.T) {
+	newTagger := func(keys *[]string) Tagger {
+		return NewReflectionTagger().
+			Add(New("my_tag").OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+				*keys = append(*keys, field.Path()+"="+field.Tag.Raw())
+
+				return data, nil
+			})).
+			Add(New("other").OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+				*keys = append(*keys, field.Path()+"=other")
+
+				return data, nil
+			}))
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test Out is the same as by reflection",
+			expect: func() bool {
+				var generatedKeys, reflectionKeys []string
+				calls := generatedCalls
+				_, generatedErr := newTagger(&generatedKeys).Out(nil, &generatedTestUser{Profile2: &generatedTestProfile{}}, "")
+				_, reflectionErr := newTagger(&reflectionKeys).Out(nil, &reflectionTestUser{Profile2: &generatedTestProfile{}}, "")
+
+				return assert.Nil(t, generatedErr) &&
+					assert.Nil(t, reflectionErr) &&
+					assert.Equal(t, calls+1, generatedCalls) &&
+					assert.Equal(t, []string{"ID=id", "Profile=profile", "Profile.Email=email", "Profile2=profile2", "Profile2.Email=email"}, generatedKeys) &&
+					assert.Equal(t, reflectionKeys, generatedKeys)
+			},
+		},
+		{
+			name: "Test tag for empty fields and selected tags",
+			expect: func() bool {
+				var keys []string
+				_, err := newTagger(&keys).Out(nil, &generatedTestUser{}, "other", "other")
+
+				return assert.Nil(t, err) && assert.Len(t, keys, 0)
+			},
+		},
+		{
+			name: "Test unknown tag for empty fields",
+			expect: func() bool {
+				var keys []string
+				_, err := newTagger(&keys).Out(nil, &generatedTestUser{}, "unknown")
+
+				return assert.EqualError(t, err, "unknown doesn't exists (empty field parser)")
+			},
+		},
+		{
+			name: "Test options of the struct registered after the first Out",
+			expect: func() bool {
+				var options []string
+				tagger := NewReflectionTagger().Add(New("my_tag").OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+					if field.Path() == "ID" {
+						options = append(options, field.StructOptions().Raw())
+					}
+
+					return data, nil
+				}))
+				_, err := tagger.Out(nil, &generatedTestUser{}, "")
+				RegisterTypeOptions((*generatedTestUser)(nil), `my_tag:"prefix:late_"`)
+				defer RegisterTypeOptions((*generatedTestUser)(nil), "")
+				_, lateErr := tagger.Out(nil, &generatedTestUser{}, "")
+
+				return assert.Nil(t, err) && assert.Nil(t, lateErr) && assert.Equal(t, []string{"", "prefix:late_"}, options)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestGeneratedStruct] %s is not true", c.name))
+		}
+	}
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	tagger := NewReflectionTagger().Add(New("my_tag").
+		InFunction(func(data any, field *Field, in *reflect.Value) error {
+			if field.Type() == reflect.Int {
+				return field.Set(1)
+			}
+
+			return nil
+		}).
+		OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			return data, nil
+		}),
+	)
+
+	b.Run("Generated In", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := tagger.In(nil, &generatedTestUser{}, ""); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Reflection In", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := tagger.In(nil, &reflectionTestUser{}, ""); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Generated Out", func(b *testing.B) {
+		b.ReportAllocs()
+		user := &generatedTestUser{ID: 1, Profile2: &generatedTestProfile{}}
+		for i := 0; i < b.N; i++ {
+			if _, err := tagger.Out(nil, user, ""); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Reflection Out", func(b *testing.B) {
+		b.ReportAllocs()
+		user := &reflectionTestUser{ID: 1, Profile2: &generatedTestProfile{}}
+		for i := 0; i < b.N; i++ {
+			if _, err := tagger.Out(nil, user, ""); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package tagger
+
+import "reflect"
+
+// BeforeTaggerIn called before the fields of the struct are filled by In (for nested structs too)
+type BeforeTaggerIn interface {
+	BeforeTaggerIn(data any) error
+}
+
+// AfterTaggerIn called after all fields of the struct (and nested structs) are filled by In.
+// For example: normalizing fields or computing derived values
+//
+//	func (u *User) AfterTaggerIn(data any) error {
+//	  u.Email = strings.ToLower(u.Email)
+//	  return nil
+//	}
+type AfterTaggerIn interface {
+	AfterTaggerIn(data any) error
+}
+
+// BeforeTaggerOut called before the fields of the struct are processed by Out (for nested structs too).
+// data is the output of the previous handlers
+type BeforeTaggerOut interface {
+	BeforeTaggerOut(data any) error
+}
+
+// AfterTaggerOut called after all fields of the struct (and nested structs) are processed by Out.
+// data is the output of the handlers
+type AfterTaggerOut interface {
+	AfterTaggerOut(data any) error
+}
+
+// hookTarget returns pointer to the struct (if it's possible) for the hooks with pointer receivers
+func hookTarget(valueOf reflect.Value) any {
+	if valueOf.CanAddr() {
+		return valueOf.Addr().Interface()
+	}
+
+	return valueOf.Interface()
+}
@@ -0,0 +1,127 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type hooksTestProfile struct {
+	Email string `hook:"email"`
+	calls *[]string
+}
+
+func (p *hooksTestProfile) BeforeTaggerIn(data any) error {
+	*p.calls = append(*p.calls, "profile before in")
+
+	return nil
+}
+
+func (p *hooksTestProfile) AfterTaggerIn(data any) error {
+	*p.calls = append(*p.calls, "profile after in: "+p.Email)
+	p.Email = strings.ToLower(p.Email)
+
+	return nil
+}
+
+func (p hooksTestProfile) AfterTaggerOut(data any) error {
+	if len(p.Email) == 0 {
+		return errors.New("email is required")
+	}
+
+	return nil
+}
+
+type hooksTestUser struct {
+	Name    string           `hook:"name"`
+	Profile hooksTestProfile `hook:"profile"`
+	Display string
+	calls   []string
+}
+
+func (u *hooksTestUser) BeforeTaggerIn(data any) error {
+	u.calls = append(u.calls, "user before in")
+	u.Profile.calls = &u.calls
+
+	return nil
+}
+
+func (u *hooksTestUser) AfterTaggerIn(data any) error {
+	u.calls = append(u.calls, "user after in")
+	u.Display = u.Name + " <" + u.Profile.Email + ">"
+
+	return nil
+}
+
+func (u *hooksTestUser) BeforeTaggerOut(data any) error {
+	if data == nil {
+		return errors.New("data is required")
+	}
+
+	return nil
+}
+
+func newHooksTestTagger() *ReflectionTagger {
+	tagger := NewReflectionTagger()
+	tagger.Add(New("hook").
+		InFunction(func(data any, field *Field, in *reflect.Value) error {
+			if field.IsStruct {
+				return nil
+			}
+
+			return field.Set(data.(string))
+		}).
+		OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			return data, nil
+		}))
+
+	return tagger
+}
+
+func TestStructHooks(t *testing.T) {
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test hooks of In for the struct and the nested struct",
+			expect: func() bool {
+				user := &hooksTestUser{}
+				err := newHooksTestTagger().In("User@Mail", user, "")
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, "user@mail", user.Profile.Email) &&
+					assert.Equal(t, "User@Mail <user@mail>", user.Display) &&
+					assert.Equal(t, []string{
+						"user before in",
+						"profile before in",
+						"profile after in: User@Mail",
+						"user after in",
+					}, user.calls)
+			},
+		},
+		{
+			name: "Test hooks of Out",
+			expect: func() bool {
+				tagger := newHooksTestTagger()
+				_, err := tagger.Out(nil, &hooksTestUser{}, "")
+				_, nestedErr := tagger.Out("data", &hooksTestUser{}, "")
+				output, validErr := tagger.Out("data", &hooksTestUser{Profile: hooksTestProfile{Email: "email"}}, "")
+
+				return assert.EqualError(t, err, "data is required") &&
+					assert.EqualError(t, nestedErr, "email is required") &&
+					assert.Nil(t, validErr) &&
+					assert.Equal(t, "data", output)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestStructHooks] %s is not true", c.name))
+		}
+	}
+}
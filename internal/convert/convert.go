@@ -0,0 +1,237 @@
+// Package convert converts strings to values of the fields and back for the tag packs
+// (query parameters, headers, CSV cells, flags).
+package convert
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// IsScalar returns true if the type is converted from a string as a whole
+// (time.Time or encoding.TextUnmarshaler for example), so it's not a nested struct for the tag packs
+func IsScalar(typeOf reflect.Type) bool {
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	if typeOf == timeType || reflect.PtrTo(typeOf).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	return typeOf.Kind() != reflect.Struct
+}
+
+// IsSlice returns true if every value is converted to an element of the type ([]byte is a single value)
+func IsSlice(typeOf reflect.Type) bool {
+	return typeOf.Kind() == reflect.Slice && typeOf.Elem().Kind() != reflect.Uint8
+}
+
+// Set converts the values and sets them to the value: all the values to a slice, the first one to other types.
+// Layout is used for time.Time (time.RFC3339 if it's empty).
+func Set(value reflect.Value, values []string, layout string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	if !IsSlice(value.Type()) || reflect.PtrTo(value.Type()).Implements(textUnmarshalerType) {
+		return SetString(value, values[0], layout)
+	}
+
+	slice := reflect.MakeSlice(value.Type(), len(values), len(values))
+	for i, raw := range values {
+		if err := SetString(slice.Index(i), raw, layout); err != nil {
+			return err
+		}
+	}
+	value.Set(slice)
+
+	return nil
+}
+
+// SetString converts the string and sets it to the value. Pointers are allocated.
+func SetString(value reflect.Value, raw string, layout string) error {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+
+		return SetString(value.Elem(), raw, layout)
+	}
+
+	switch value.Type() {
+	case durationType:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+
+		return nil
+	case timeType:
+		if len(layout) == 0 {
+			layout = time.RFC3339
+		}
+
+		parsed, err := time.Parse(layout, raw)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(parsed))
+
+		return nil
+	}
+
+	if value.CanAddr() && value.Addr().Type().Implements(textUnmarshalerType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.Uint8 {
+			return errors.New(fmt.Sprintf("unsupported type %s", value.Type()))
+		}
+		value.SetBytes([]byte(raw))
+	default:
+		return errors.New(fmt.Sprintf("unsupported type %s", value.Type()))
+	}
+
+	return nil
+}
+
+// Strings formats the value: every element of a slice, nothing for nil pointers.
+// Format is a layout for time.Time or a fmt verb for numbers ("%.2f" for example).
+func Strings(value reflect.Value, format string) ([]string, error) {
+	if !IsSlice(value.Type()) || reflect.PtrTo(value.Type()).Implements(textMarshalerType) {
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return nil, nil
+		}
+
+		formatted, err := String(value, format)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{formatted}, nil
+	}
+
+	output := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		formatted, err := String(value.Index(i), format)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, formatted)
+	}
+
+	return output, nil
+}
+
+// String formats the value. Nil pointer is an empty string.
+// Format is a layout for time.Time or a fmt verb for numbers ("%.2f" for example).
+func String(value reflect.Value, format string) (string, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", nil
+		}
+
+		return String(value.Elem(), format)
+	}
+
+	switch value.Type() {
+	case durationType:
+		return time.Duration(value.Int()).String(), nil
+	case timeType:
+		if len(format) == 0 {
+			format = time.RFC3339
+		}
+
+		return value.Interface().(time.Time).Format(format), nil
+	}
+
+	if marshaler, ok := textMarshaler(value); ok {
+		raw, err := marshaler.MarshalText()
+
+		return string(raw), err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if len(format) != 0 {
+			return fmt.Sprintf(format, value.Interface()), nil
+		}
+
+		return fmt.Sprint(value.Interface()), nil
+	case reflect.Float32, reflect.Float64:
+		if len(format) != 0 {
+			return fmt.Sprintf(format, value.Interface()), nil
+		}
+
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), nil
+		}
+	}
+
+	return "", errors.New(fmt.Sprintf("unsupported type %s", value.Type()))
+}
+
+func textMarshaler(value reflect.Value) (encoding.TextMarshaler, bool) {
+	if !value.CanInterface() {
+		return nil, false
+	}
+
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		return marshaler, true
+	}
+
+	if value.CanAddr() {
+		marshaler, ok := value.Addr().Interface().(encoding.TextMarshaler)
+
+		return marshaler, ok
+	}
+
+	return nil, false
+}
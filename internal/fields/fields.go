@@ -0,0 +1,92 @@
+// Package fields helps the tag packs with the fields passed to the handlers by the engine
+// (query parameters, headers, CSV cells, SQL columns, flags).
+package fields
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/internal/convert"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Value returns value of the field (the engine passes nested structs by pointers)
+func Value(field *tagger.Field) reflect.Value {
+	if field.StructField.Type.Kind() != reflect.Ptr && field.Value.Kind() == reflect.Ptr {
+		return field.Value.Elem()
+	}
+
+	return field.Value
+}
+
+// IsColumn returns false for nested structs: their fields are columns.
+// time.Time and structs which implement one of the interfaces (by the pointer) are columns.
+//
+//	fields.IsColumn(field, scannerType, valuerType)
+func IsColumn(field *tagger.Field, interfaces ...reflect.Type) bool {
+	typeOf := field.StructField.Type
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	if !field.IsStruct || typeOf == timeType {
+		return true
+	}
+
+	for _, i := range interfaces {
+		if reflect.PtrTo(typeOf).Implements(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rest parses the parts of the tag after the name as a tag: `csv:"date,format=2006-01-02"` -> format=2006-01-02
+func Rest(field *tagger.Field, parts []string) tagger.FieldTag {
+	rest := tagger.FieldTag{TagSymbols: field.Tag.TagSymbols}
+	if len(parts) > 1 {
+		rest.StructTag = reflect.StructTag(strings.Join(parts[1:], field.Tag.TagSymbols.KeysSeparator))
+	}
+	rest.Parse()
+
+	return rest
+}
+
+// Allocate sets new structs to nil pointers of the nested structs (recursive types are allocated only once)
+func Allocate(valueOf reflect.Value) {
+	allocate(valueOf, make(map[reflect.Type]bool))
+}
+
+func allocate(valueOf reflect.Value, parents map[reflect.Type]bool) {
+	for valueOf.Kind() == reflect.Ptr && !valueOf.IsNil() {
+		valueOf = valueOf.Elem()
+	}
+
+	if valueOf.Kind() != reflect.Struct || parents[valueOf.Type()] {
+		return
+	}
+
+	parents[valueOf.Type()] = true
+	defer delete(parents, valueOf.Type())
+
+	for i := 0; i < valueOf.NumField(); i++ {
+		field := valueOf.Field(i)
+		structField := valueOf.Type().Field(i)
+		if !field.CanSet() || convert.IsScalar(structField.Type) {
+			continue
+		}
+
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			if parents[structField.Type.Elem()] {
+				continue
+			}
+			field.Set(reflect.New(structField.Type.Elem()))
+		}
+
+		allocate(field, parents)
+	}
+}
@@ -0,0 +1,78 @@
+package tagger
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrDefaultHandler returned by TaggerUnmarshaler/TaggerMarshaler for to call the registered handler of the tag
+var ErrDefaultHandler = errors.New("use the handler of the tag")
+
+// TaggerUnmarshaler field type which fills itself by In instead of the handler of the tag (like json.Unmarshaler).
+// Fields of a nested struct which implements it aren't processed when it fills the struct (doesn't return ErrDefaultHandler).
+//
+//	func (m *Money) UnmarshalTagger(tagName string, fieldTag FieldTag, data any) error {
+//	  if tagName != "my_json" {
+//	    return ErrDefaultHandler
+//	  }
+//	  return m.Parse(data.(map[string]any)[fieldTag.Raw()])
+//	}
+type TaggerUnmarshaler interface {
+	UnmarshalTagger(tagName string, fieldTag FieldTag, data any) error
+}
+
+// TaggerMarshaler field type which exports itself by Out instead of the handler of the tag.
+// Returns the data for the next handlers (as a handler for Out).
+// Fields of a nested struct which implements it aren't processed when it exports the struct (doesn't return ErrDefaultHandler).
+type TaggerMarshaler interface {
+	MarshalTagger(tagName string, fieldTag FieldTag, data any) (any, error)
+}
+
+// fieldTarget returns value of the field (pointer to it if it's possible) for the interfaces of the field type
+func fieldTarget(field *Field) (any, bool) {
+	if !field.Value.IsValid() || !field.Value.CanInterface() {
+		return nil, false
+	}
+
+	if field.Value.Kind() == reflect.Ptr {
+		return field.Value.Interface(), !field.Value.IsNil()
+	}
+
+	if field.Value.CanAddr() {
+		return field.Value.Addr().Interface(), true
+	}
+
+	return field.Value.Interface(), true
+}
+
+// callMarshaler calls TaggerUnmarshaler/TaggerMarshaler of the field type. handled is false if the type doesn't
+// implement the interface or ErrDefaultHandler is returned
+func callMarshaler(call Call) (output any, handled bool, err error) {
+	output = call.Data
+	target, ok := fieldTarget(call.Field)
+	if !ok {
+		return
+	}
+
+	if call.Direction == DirectionIn {
+		unmarshaler, ok := target.(TaggerUnmarshaler)
+		if !ok {
+			return
+		}
+
+		err = unmarshaler.UnmarshalTagger(call.Tag.Name, call.Field.Tag, call.Data)
+	} else {
+		marshaler, ok := target.(TaggerMarshaler)
+		if !ok {
+			return
+		}
+
+		output, err = marshaler.MarshalTagger(call.Tag.Name, call.Field.Tag, call.Data)
+	}
+
+	if errors.Is(err, ErrDefaultHandler) {
+		return call.Data, false, nil
+	}
+
+	return output, true, err
+}
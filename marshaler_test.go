@@ -0,0 +1,139 @@
+package tagger
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type marshalerTestMoney int64
+
+func (m *marshalerTestMoney) UnmarshalTagger(tagName string, fieldTag FieldTag, data any) error {
+	amount, err := strconv.ParseFloat(data.(map[string]string)[fieldTag.Raw()], 64)
+	*m = marshalerTestMoney(amount * 100)
+
+	return err
+}
+
+func (m marshalerTestMoney) MarshalTagger(tagName string, fieldTag FieldTag, data any) (any, error) {
+	return append(data.([]string), fmt.Sprintf("%s=%d.%02d", fieldTag.Raw(), m/100, m%100)), nil
+}
+
+type marshalerTestID string
+
+// Only my_id is processed by the type
+func (id *marshalerTestID) UnmarshalTagger(tagName string, fieldTag FieldTag, data any) error {
+	if tagName != "my_id" {
+		return ErrDefaultHandler
+	}
+
+	*id = marshalerTestID("id-" + data.(map[string]string)[fieldTag.Raw()])
+
+	return nil
+}
+
+type marshalerTestAddress struct {
+	City   string `mar:"city"`
+	Street string `mar:"street"`
+}
+
+func (a *marshalerTestAddress) UnmarshalTagger(tagName string, fieldTag FieldTag, data any) error {
+	parts := strings.Split(data.(map[string]string)[fieldTag.Raw()], ", ")
+	a.City, a.Street = parts[0], parts[1]
+
+	return nil
+}
+
+// Fields are always processed by the handlers of the tags
+type marshalerTestLocation struct {
+	City marshalerTestID `mar:"city"`
+}
+
+func (l *marshalerTestLocation) UnmarshalTagger(tagName string, fieldTag FieldTag, data any) error {
+	return ErrDefaultHandler
+}
+
+func (l marshalerTestLocation) MarshalTagger(tagName string, fieldTag FieldTag, data any) (any, error) {
+	return nil, ErrDefaultHandler
+}
+
+type marshalerTestProfile struct {
+	Location marshalerTestLocation
+}
+
+type marshalerTestOrder struct {
+	Total   marshalerTestMoney    `mar:"total"`
+	ID      marshalerTestID       `mar:"id"`
+	OwnerID marshalerTestID       `my_id:"owner"`
+	Address *marshalerTestAddress `mar:"address"`
+}
+
+func TestTaggerMarshalers(t *testing.T) {
+	handlerIn := func(data any, field *Field, in *reflect.Value) error {
+		if field.Type() == reflect.String {
+			return field.Set(marshalerTestID("handler-" + data.(map[string]string)[field.Tag.Raw()]))
+		}
+
+		return fmt.Errorf("unexpected call of the handler for %s", field.Name())
+	}
+	tagger := NewReflectionTagger().
+		Add(New("mar").InFunction(handlerIn).OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			return append(data.([]string), "handler="+field.Tag.Raw()), nil
+		})).
+		Add(New("my_id").InFunction(handlerIn))
+	data := map[string]string{"total": "12.34", "id": "1", "owner": "2", "address": "Kyiv, Main", "city": "-"}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test In by the field types",
+			expect: func() bool {
+				order := &marshalerTestOrder{}
+				err := tagger.In(data, order, "")
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, marshalerTestMoney(1234), order.Total) &&
+					assert.Equal(t, marshalerTestID("handler-1"), order.ID) &&
+					assert.Equal(t, marshalerTestID("id-2"), order.OwnerID) &&
+					assert.Equal(t, &marshalerTestAddress{City: "Kyiv", Street: "Main"}, order.Address)
+			},
+		},
+		{
+			name: "Test Out by the field types",
+			expect: func() bool {
+				output, err := tagger.Out([]string{}, &marshalerTestOrder{Total: 1205, ID: "1", Address: &marshalerTestAddress{}}, "", "mar")
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, []string{"total=12.05", "handler=id", "handler=address", "handler=city", "handler=street"}, output)
+			},
+		},
+		{
+			name: "Test In of the nested struct when its type doesn't handle the value",
+			expect: func() bool {
+				profile := &marshalerTestProfile{}
+				err := tagger.In(data, profile, "")
+
+				return assert.Nil(t, err) && assert.Equal(t, marshalerTestID("handler--"), profile.Location.City)
+			},
+		},
+		{
+			name: "Test Out of the nested struct when its type doesn't handle the value",
+			expect: func() bool {
+				output, err := tagger.Out([]string{}, &marshalerTestProfile{}, "", "mar")
+
+				return assert.Nil(t, err) && assert.Equal(t, []string{"handler=city"}, output)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestTaggerMarshalers] %s is not true", c.name))
+		}
+	}
+}
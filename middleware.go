@@ -0,0 +1,88 @@
+package tagger
+
+import "reflect"
+
+// Direction of the processing
+type Direction uint8
+
+const (
+	DirectionIn Direction = iota
+	DirectionOut
+)
+
+func (d Direction) String() string {
+	if d == DirectionIn {
+		return "In"
+	}
+
+	return "Out"
+}
+
+// Call invocation of a handler of the tag for a field
+type Call struct {
+	Direction Direction
+	Tag       *Tag
+	Field     *Field
+	// data passed to In/Out (output of the previous handler of the field for Out)
+	Data any
+	// Struct which contains the field
+	Struct *reflect.Value
+}
+
+// HandlerCall calls the handler. Output is the data for the next handlers of Out (ignored for In).
+type HandlerCall func(call Call) (output any, err error)
+
+// Middleware wraps every handler call (timing, tracing, audit logging)
+//
+//	tagger.Use(func(next HandlerCall) HandlerCall {
+//	  return func(call Call) (any, error) {
+//	    started := time.Now()
+//	    defer func() { log.Println(call.Tag.Name, call.Field.Name(), time.Since(started)) }()
+//
+//	    return next(call)
+//	  }
+//	})
+type Middleware func(next HandlerCall) HandlerCall
+
+// callHandler calls TaggerUnmarshaler/TaggerMarshaler of the field type or the handler of the tag for the direction
+func callHandler(call Call) (any, error) {
+	if output, handled, err := callMarshaler(call); handled {
+		call.Field.marshaled = true
+
+		return output, err
+	}
+
+	return handle(call)
+}
+
+// handle calls the handler of the tag for the direction
+func handle(call Call) (any, error) {
+	tag := call.Tag
+	if call.Direction == DirectionIn {
+		if tag.InHandlerF != nil {
+			return call.Data, tag.InHandlerF(call.Data, call.Field, call.Struct)
+		}
+
+		return call.Data, tag.InHandlerC.Handle(call.Data, call.Field, call.Struct)
+	}
+
+	if tag.OutHandlerF != nil {
+		return tag.OutHandlerF(call.Data, call.Field, call.Struct)
+	}
+
+	return tag.OutHandlerC.Handle(call.Data, call.Field, call.Struct)
+}
+
+// Use adds middlewares around every handler call. The first added middleware is the outermost.
+func (r *ReflectionTagger) Use(middlewares ...Middleware) *ReflectionTagger {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.middlewares = append(append([]Middleware{}, r.middlewares...), middlewares...)
+	r.call = callHandler
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		r.call = r.middlewares[i](r.call)
+	}
+
+	return r
+}
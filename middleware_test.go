@@ -0,0 +1,84 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReflectionTaggerUse(t *testing.T) {
+	record := func(name string, calls *[]string) Middleware {
+		return func(next HandlerCall) HandlerCall {
+			return func(call Call) (any, error) {
+				*calls = append(*calls, fmt.Sprintf("%s %s %s %s", name, call.Direction, call.Tag.Name, call.Field.Name()))
+
+				return next(call)
+			}
+		}
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test middlewares are called in order for every handler",
+			expect: func() bool {
+				var calls []string
+				tagger := newRegistryTestTagger().Use(record("first", &calls), record("second", &calls))
+				user := &registryTestUser{}
+				err := tagger.In(1, user, "", "reg_in")
+				_, outErr := tagger.Out([]string{}, user, "", "reg_out")
+
+				return assert.Nil(t, err) &&
+					assert.Nil(t, outErr) &&
+					assert.Equal(t, 1, user.ID) &&
+					assert.Equal(t, []string{
+						"first In reg_in Username", "second In reg_in Username",
+						"first In reg_in ID", "second In reg_in ID",
+						"first Out reg_out Username", "second Out reg_out Username",
+						"first Out reg_out ID", "second Out reg_out ID",
+					}, calls)
+			},
+		},
+		{
+			name: "Test middleware can replace the call",
+			expect: func() bool {
+				tagger := newRegistryTestTagger().Use(func(next HandlerCall) HandlerCall {
+					return func(call Call) (any, error) {
+						if call.Field.Name() == "ID" {
+							return call.Data, errors.New("skipped")
+						}
+
+						return next(call)
+					}
+				})
+				output, err := tagger.Out([]string{}, &registryTestUser{Username: "user"}, "", "reg_out")
+
+				return assert.EqualError(t, err, "skipped") &&
+					assert.Equal(t, []string{"user"}, output) &&
+					assert.EqualError(t, tagger.In(1, &registryTestUser{}, "", "reg_in"), "skipped")
+			},
+		},
+		{
+			name: "Test middlewares of a clone",
+			expect: func() bool {
+				var calls []string
+				base := newRegistryTestTagger()
+				clone := base.Clone().Use(record("clone", &calls))
+
+				return assert.Nil(t, base.In(1, &registryTestUser{}, "", "reg_in")) &&
+					assert.Empty(t, calls) &&
+					assert.Nil(t, clone.In(1, &registryTestUser{}, "", "reg_in")) &&
+					assert.Len(t, calls, 2)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerUse] %s is not true", c.name))
+		}
+	}
+}
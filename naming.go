@@ -0,0 +1,150 @@
+package tagger
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy derives a key from the name of a Go field: UserID -> user_id
+type NamingStrategy func(name string) string
+
+var (
+	// SnakeCase HTTPServerURL -> http_server_url
+	SnakeCase NamingStrategy = func(name string) string {
+		return joinWords(name, "_", strings.ToLower)
+	}
+	// KebabCase HTTPServerURL -> http-server-url
+	KebabCase NamingStrategy = func(name string) string {
+		return joinWords(name, "-", strings.ToLower)
+	}
+	// ScreamingSnakeCase HTTPServerURL -> HTTP_SERVER_URL
+	ScreamingSnakeCase NamingStrategy = func(name string) string {
+		return joinWords(name, "_", strings.ToUpper)
+	}
+	// CamelCase HTTPServerURL -> httpServerURL (acronyms are kept after the first word)
+	CamelCase NamingStrategy = func(name string) string {
+		words := splitWords(name)
+		for i, word := range words {
+			switch {
+			case i == 0:
+				words[i] = strings.ToLower(word)
+			case strings.ToUpper(word) != word:
+				runes := []rune(strings.ToLower(word))
+				runes[0] = unicode.ToUpper(runes[0])
+				words[i] = string(runes)
+			}
+		}
+
+		return strings.Join(words, "")
+	}
+)
+
+// namingKey key of the struct options for the naming strategy: _ struct{} `my_json:"naming:camelCase"`
+const namingKey = "naming"
+
+// namingStrategies by names for the struct options
+var namingStrategies = map[string]NamingStrategy{
+	"snake_case":           SnakeCase,
+	"kebab-case":           KebabCase,
+	"SCREAMING_SNAKE_CASE": ScreamingSnakeCase,
+	"camelCase":            CamelCase,
+}
+
+// NamingStrategyByName returns strategy by the name: snake_case, kebab-case, SCREAMING_SNAKE_CASE, camelCase
+func NamingStrategyByName(name string) (NamingStrategy, bool) {
+	strategy, exists := namingStrategies[name]
+
+	return strategy, exists
+}
+
+func joinWords(name, separator string, convert func(string) string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		words[i] = convert(word)
+	}
+
+	return strings.Join(words, separator)
+}
+
+// splitWords splits the name to words by case changes, acronyms and separators: HTTPServer2URL -> HTTP, Server2, URL
+func splitWords(name string) (words []string) {
+	runes := []rune(name)
+	start := 0
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+
+			continue
+		}
+
+		if i == start || !unicode.IsUpper(r) {
+			continue
+		}
+
+		previous := runes[i-1]
+		// userName, Auth2FA or the last letter of an acronym before a word: HTTPServer
+		acronymEnd := unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(previous) || unicode.IsDigit(previous) || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return
+}
+
+// Naming sets the default naming strategy for Field.Key
+func (r *ReflectionTagger) Naming(strategy NamingStrategy) *ReflectionTagger {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.naming = strategy
+
+	return r
+}
+
+// Key returns the name of the field for the tag: the first part of the tag if it's not a key with value
+// (`my_json:"user_id"` -> user_id) or the name derived by the naming strategy of the struct options
+// (`my_json:"naming:camelCase"`), the tag or the tagger. The name of the field if there is no strategy.
+//
+//	key := field.Key("my_json")
+func (f Field) Key(tagName string) string {
+	tag, exists := f.tags[tagName]
+	if !exists {
+		tag = New(tagName)
+	}
+
+	fieldTag := f.Tag
+	tag.parse(&fieldTag)
+	if parts := fieldTag.Parts(); len(parts) != 0 {
+		if _, _, isKeyValue := splitKeyValue(parts[0], fieldTag.TagSymbols); !isKeyValue {
+			if name := unquoteTag(parts[0], fieldTag.TagSymbols); len(name) != 0 && name != ignoreKey {
+				return name
+			}
+		}
+	}
+
+	strategy := f.naming
+	if tag.NamingStrategy != nil {
+		strategy = tag.NamingStrategy
+	}
+
+	if name, exists := tag.structOptions(f.structTag).FindByKey(namingKey); exists {
+		if byName, exists := NamingStrategyByName(name); exists {
+			strategy = byName
+		}
+	}
+
+	if strategy == nil {
+		return f.Name()
+	}
+
+	return strategy(f.Name())
+}
@@ -0,0 +1,119 @@
+package tagger
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestNamingStrategies(t *testing.T) {
+	cases := []struct {
+		name     string
+		strategy NamingStrategy
+		expect   map[string]string
+	}{
+		{
+			name:     "Test snake_case",
+			strategy: SnakeCase,
+			expect: map[string]string{
+				"UserID":        "user_id",
+				"HTTPServerURL": "http_server_url",
+				"userName":      "user_name",
+				"Address2":      "address2",
+				"Auth2FA":       "auth2_fa",
+				"ID":            "id",
+				"Already_Snake": "already_snake",
+			},
+		},
+		{
+			name:     "Test kebab-case",
+			strategy: KebabCase,
+			expect:   map[string]string{"HTTPServerURL": "http-server-url", "CreatedAt": "created-at"},
+		},
+		{
+			name:     "Test SCREAMING_SNAKE_CASE",
+			strategy: ScreamingSnakeCase,
+			expect:   map[string]string{"HTTPServerURL": "HTTP_SERVER_URL", "CreatedAt": "CREATED_AT"},
+		},
+		{
+			name:     "Test camelCase",
+			strategy: CamelCase,
+			expect: map[string]string{
+				"HTTPServerURL": "httpServerURL",
+				"UserID":        "userID",
+				"ID":            "id",
+				"created_at":    "createdAt",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual := make(map[string]string)
+		for name := range c.expect {
+			actual[name] = c.strategy(name)
+		}
+
+		if !assert.Equal(t, c.expect, actual) {
+			t.Error(fmt.Sprintf("[TestNamingStrategies] %s is not true", c.name))
+		}
+	}
+}
+
+type namingTestProfile struct {
+	_         struct{} `key:"naming:kebab-case"`
+	CreatedAt string
+}
+
+type namingTestUser struct {
+	UserID    string `key:"id"`
+	HTTPProxy string `key:"-"`
+	FirstName string `key:"required:true"`
+	Profile   namingTestProfile
+}
+
+func TestFieldKey(t *testing.T) {
+	collect := func(keys *[]string) *Tag {
+		return New("key").Symbols(":", ",").InFunction(func(data any, field *Field, in *reflect.Value) error {
+			*keys = append(*keys, field.Key("key"))
+
+			return nil
+		})
+	}
+
+	cases := []struct {
+		name   string
+		tagger func(keys *[]string) Tagger
+		expect []string
+	}{
+		{
+			name: "Test names of the fields without strategies",
+			tagger: func(keys *[]string) Tagger {
+				return NewReflectionTagger().Add(collect(keys))
+			},
+			expect: []string{"id", "HTTPProxy", "FirstName", "Profile", "created-at"},
+		},
+		{
+			name: "Test strategy of the tagger",
+			tagger: func(keys *[]string) Tagger {
+				return NewReflectionTagger().Naming(SnakeCase).Add(collect(keys))
+			},
+			expect: []string{"id", "http_proxy", "first_name", "profile", "created-at"},
+		},
+		{
+			name: "Test strategy of the tag overrides the tagger",
+			tagger: func(keys *[]string) Tagger {
+				return NewReflectionTagger().Naming(SnakeCase).Add(collect(keys).Naming(ScreamingSnakeCase))
+			},
+			expect: []string{"id", "HTTP_PROXY", "FIRST_NAME", "PROFILE", "created-at"},
+		},
+	}
+
+	for _, c := range cases {
+		var keys []string
+		err := c.tagger(&keys).In(nil, &namingTestUser{}, "key")
+		if !assert.Nil(t, err) || !assert.Equal(t, c.expect, keys) {
+			t.Error(fmt.Sprintf("[TestFieldKey] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package tagger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OptionKind type of value for a key of the tag
+type OptionKind uint8
+
+const (
+	StringOption OptionKind = iota
+	IntOption
+	BoolOption
+	DurationOption
+	RegexpOption
+)
+
+// Option described key of the tag. Tag with options accepts only described keys.
+//
+//	New("my_logger").Options(
+//	  Opt("key").Required(),
+//	  Opt("to").OneOf("string", "int"),
+//	  Opt("limit").Int().Default("10"),
+//	)
+type Option struct {
+	Name string
+	Kind OptionKind
+	// Value which will be used when the key isn't defined in the field tags
+	DefaultValue string
+	HasDefault   bool
+	IsRequired   bool
+	// The key can be defined more than once: "alias:a | alias:b" or "alias:[a, b]"
+	IsMultiple bool
+	// Allowed values. Any value is allowed if it's empty
+	Allowed []string
+}
+
+// Int value of the key must be integer
+func (o *Option) Int() *Option {
+	o.Kind = IntOption
+
+	return o
+}
+
+// Bool value of the key must be boolean or the key is a flag: "omitempty"
+func (o *Option) Bool() *Option {
+	o.Kind = BoolOption
+
+	return o
+}
+
+// Duration value of the key must be time.Duration: "1m30s"
+func (o *Option) Duration() *Option {
+	o.Kind = DurationOption
+
+	return o
+}
+
+// Regexp value of the key must be regular expression
+func (o *Option) Regexp() *Option {
+	o.Kind = RegexpOption
+
+	return o
+}
+
+// Required the key must be defined for every field with the tag
+func (o *Option) Required() *Option {
+	o.IsRequired = true
+
+	return o
+}
+
+// Multiple the key can have several values: "alias:[a, b]"
+func (o *Option) Multiple() *Option {
+	o.IsMultiple = true
+
+	return o
+}
+
+// OneOf value of the key must be one of values
+func (o *Option) OneOf(values ...string) *Option {
+	o.Allowed = values
+
+	return o
+}
+
+// Default value of the key when it isn't defined
+func (o *Option) Default(value string) *Option {
+	o.DefaultValue = value
+	o.HasDefault = true
+
+	return o
+}
+
+// Validate values of the key from the field tags
+func (o *Option) Validate(fieldTag FieldTag) error {
+	values := fieldTag.FindAllByKey(o.Name)
+	if len(values) > 1 && !o.IsMultiple {
+		return fieldTag.optionError(o.Name, "Key is defined more than once")
+	}
+
+	for _, value := range values {
+		if err := o.validateValue(fieldTag, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Option) validateValue(fieldTag FieldTag, value string) (err error) {
+	if len(o.Allowed) != 0 {
+		if _, err = fieldTag.toEnum(o.Name, value, o.Allowed); err != nil {
+			return
+		}
+	}
+
+	switch o.Kind {
+	case IntOption:
+		_, err = fieldTag.toInt(o.Name, value)
+	case BoolOption:
+		_, err = fieldTag.toBool(o.Name, value)
+	case DurationOption:
+		_, err = fieldTag.toDuration(o.Name, value)
+	case RegexpOption:
+		_, err = fieldTag.toRegexp(o.Name, value)
+	}
+
+	return
+}
+
+// Opt initialize of an option
+//
+//	Opt("key")
+func Opt(name string) *Option {
+	return &Option{
+		Name: name,
+	}
+}
+
+// ignoreKey can be used for any tag without declaring: `my_json:"-"`
+const ignoreKey = "-"
+
+// findOption returns declared option by the key of the field tags
+func (t *Tag) findOption(fieldTag FieldTag, key string) (*Option, bool) {
+	for _, option := range t.Schema {
+		if fieldTag.normalizeKey(option.Name) == key {
+			return option, true
+		}
+	}
+
+	return nil, false
+}
+
+// applyDefaults adds default values of the options which aren't defined in the field tags
+func (t *Tag) applyDefaults(fieldTag *FieldTag) {
+	for _, option := range t.Schema {
+		if !option.HasDefault || fieldTag.Exists(option.Name) {
+			continue
+		}
+
+		fieldTag.ParsedTags = append(fieldTag.ParsedTags, [2]string{fieldTag.normalizeKey(option.Name), option.DefaultValue})
+		fieldTag.OriginalKeys = append(fieldTag.OriginalKeys, option.Name)
+	}
+}
+
+// Validate the field tags by the declared options
+func (t *Tag) Validate(fieldTag FieldTag) error {
+	// Ignored field: `my_json:"-"`
+	if len(t.Schema) == 0 || fieldTag.Raw() == ignoreKey {
+		return nil
+	}
+
+	for i, parsed := range fieldTag.ParsedTags {
+		if parsed[0] == ignoreKey {
+			continue
+		}
+
+		if _, exists := t.findOption(fieldTag, parsed[0]); !exists {
+			names := make([]string, 0, len(t.Schema))
+			for _, option := range t.Schema {
+				names = append(names, option.Name)
+			}
+
+			return fieldTag.optionError(fieldTag.OriginalKeys[i], fmt.Sprintf(
+				"Unknown key. Available: %s", strings.Join(names, ", "),
+			))
+		}
+	}
+
+	for _, option := range t.Schema {
+		if !fieldTag.Exists(option.Name) {
+			if option.IsRequired {
+				return fieldTag.optionError(option.Name, "Key is required")
+			}
+
+			continue
+		}
+
+		if err := option.Validate(fieldTag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type optionTestProfile struct {
+	Email string `my_logger:"kye:email"`
+}
+
+type optionTestUser struct {
+	Username string `my_logger:"key:username | to:string"`
+}
+
+type optionTestNested struct {
+	Username string             `my_logger:"key:username"`
+	Profile  *optionTestProfile `my_logger:"key:profile"`
+}
+
+type optionTestInvalidValue struct {
+	Username string `my_logger:"key:username | to:bytes"`
+}
+
+type optionTestRequired struct {
+	Username string `my_logger:"to:string"`
+}
+
+type optionTestRepeated struct {
+	Username string `my_logger:"key:username | to:string | to:int"`
+}
+
+type optionTestMultiple struct {
+	Username string `my_logger:"key:username | alias:[login, name]"`
+}
+
+type optionTestBrackets struct {
+	Username string `my_logger:"key:[username] | alias:[login, name]"`
+}
+
+type optionTestKind struct {
+	Count int `my_logger:"key:count"`
+}
+
+type optionTestConflict struct {
+	Username string `my_logger:"key:username" my_json:"username"`
+}
+
+type optionTestDefaults struct {
+	Username string `my_logger:"key:username"`
+}
+
+func TestTag_Options(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  any
+		expect func(keys map[string][2]string, err error) bool
+	}{
+		{
+			name:  "Test valid tags",
+			value: &optionTestUser{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, [2]string{"username", "string"}, keys["Username"])
+			},
+		},
+		{
+			name:  "Test unknown key in the nested struct",
+			value: &optionTestNested{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Equal(t, errors.New(
+					"tagger.optionTestProfile: Incorrect value of the key: kye (tag: my_logger, field: Email). "+
+						"Unknown key. Available: key, to, limit, alias",
+				), err) && assert.Len(t, keys, 0)
+			},
+		},
+		{
+			name:  "Test not allowed value",
+			value: &optionTestInvalidValue{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Equal(t, errors.New(
+					"tagger.optionTestInvalidValue: Incorrect value of the key: to (tag: my_logger, field: Username). "+
+						"Expected one of: string, int. Actual: bytes",
+				), err)
+			},
+		},
+		{
+			name:  "Test required key",
+			value: &optionTestRequired{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Equal(t, errors.New(
+					"tagger.optionTestRequired: Incorrect value of the key: key (tag: my_logger, field: Username). "+
+						"Key is required",
+				), err)
+			},
+		},
+		{
+			name:  "Test repeated key",
+			value: &optionTestRepeated{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Equal(t, errors.New(
+					"tagger.optionTestRepeated: Incorrect value of the key: to (tag: my_logger, field: Username). "+
+						"Key is defined more than once",
+				), err)
+			},
+		},
+		{
+			name:  "Test multiple key",
+			value: &optionTestMultiple{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Nil(t, err)
+			},
+		},
+		{
+			name:  "Test brackets of the key which isn't multiple",
+			value: &optionTestBrackets{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, [2]string{"[username]", "string"}, keys["Username"])
+			},
+		},
+		{
+			name:  "Test kinds are checked only by the analyzer",
+			value: &optionTestKind{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, [2]string{"count", "string"}, keys["Count"])
+			},
+		},
+		{
+			name:  "Test conflicts are checked only by the analyzer",
+			value: &optionTestConflict{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, [2]string{"username", "string"}, keys["Username"])
+			},
+		},
+		{
+			name:  "Test default values",
+			value: &optionTestDefaults{},
+			expect: func(keys map[string][2]string, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, [2]string{"username", "string"}, keys["Username"])
+			},
+		},
+	}
+
+	for _, c := range cases {
+		keys := make(map[string][2]string)
+		tag := NewReflectionTagger().Add(New("my_logger").
+			Symbols(":", " | ").
+			ForKinds(reflect.String, reflect.Struct).
+			ConflictsWith("my_json").
+			Options(
+				Opt("key").Required(),
+				Opt("to").OneOf("string", "int").Default("string"),
+				Opt("limit").Int(),
+				Opt("alias").Multiple(),
+			).
+			OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+				key, _ := field.Tag.FindByKey("key")
+				to, _ := field.Tag.FindByKey("to")
+				keys[field.Name()] = [2]string{key, to}
+
+				return data, nil
+			}),
+		)
+
+		_, err := tag.Out(nil, c.value, "")
+		if !c.expect(keys, err) {
+			t.Error(fmt.Sprintf("[TestTag_Options] %s is not true", c.name))
+		}
+	}
+}
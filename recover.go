@@ -0,0 +1,56 @@
+package tagger
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError panic recovered in a handler or in the tagger (see Recover)
+type PanicError struct {
+	// Names of the fields from the root struct: Profile.Email. Empty if the panic isn't in a handler
+	Path string
+	// Name of the tag which handler panicked. Empty if the panic isn't in a handler
+	Tag   string
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	if len(e.Tag) == 0 {
+		return fmt.Sprintf("Panic while processing: %v", e.Value)
+	}
+
+	return fmt.Sprintf("Panic in the handler of the tag %s (field %s): %v", e.Tag, e.Path, e.Value)
+}
+
+// Unwrap returns the panic value if it's an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+// Recover panics in handlers and in the tagger are returned from In/Out as *PanicError
+func (r *ReflectionTagger) Recover() *ReflectionTagger {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.recover = true
+
+	return r
+}
+
+// recoverPanic must be deferred: sets the recovered panic to the error
+func recoverPanic(err *error, call *Call) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	panicErr := &PanicError{Value: value, Stack: debug.Stack()}
+	if call != nil {
+		panicErr.Path = call.Field.Path()
+		panicErr.Tag = call.Tag.Name
+	}
+	*err = panicErr
+}
@@ -0,0 +1,95 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type recoverTestProfile struct {
+	Email string `rec:"email"`
+}
+
+type recoverTestUser struct {
+	Name    string              `rec:"name"`
+	Profile *recoverTestProfile `rec:"profile"`
+}
+
+func newRecoverTestTagger() *ReflectionTagger {
+	tagger := NewReflectionTagger()
+	tagger.Add(New("rec").
+		InFunction(func(data any, field *Field, in *reflect.Value) error {
+			if field.IsStruct {
+				return nil
+			}
+
+			return field.Set(data.(string))
+		}).
+		OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			if field.Tag.Raw() == "email" {
+				panic(errors.New("email can't be exported"))
+			}
+
+			return data, nil
+		}))
+
+	return tagger
+}
+
+func TestReflectionTaggerRecover(t *testing.T) {
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test panic of the handler",
+			expect: func() bool {
+				err := newRecoverTestTagger().Recover().In(1, &recoverTestUser{}, "")
+				panicErr := &PanicError{}
+
+				return assert.ErrorAs(t, err, &panicErr) &&
+					assert.Equal(t, "Name", panicErr.Path) &&
+					assert.Equal(t, "rec", panicErr.Tag) &&
+					assert.NotEmpty(t, panicErr.Stack) &&
+					assert.Contains(t, err.Error(), "Panic in the handler of the tag rec (field Name): interface conversion")
+			},
+		},
+		{
+			name: "Test panic of the handler in a nested struct",
+			expect: func() bool {
+				output, err := newRecoverTestTagger().Recover().Out("data", &recoverTestUser{Profile: &recoverTestProfile{}}, "")
+				panicErr := &PanicError{}
+
+				return assert.ErrorAs(t, err, &panicErr) &&
+					assert.Equal(t, "Profile.Email", panicErr.Path) &&
+					assert.EqualError(t, errors.Unwrap(err), "email can't be exported") &&
+					assert.Equal(t, "data", output)
+			},
+		},
+		{
+			name: "Test panic of the tagger",
+			expect: func() bool {
+				err := newRecoverTestTagger().Recover().In("name", recoverTestUser{}, "")
+				panicErr := &PanicError{}
+
+				return assert.ErrorAs(t, err, &panicErr) &&
+					assert.Empty(t, panicErr.Tag) &&
+					assert.Contains(t, err.Error(), "Panic while processing: ")
+			},
+		},
+		{
+			name: "Test panics aren't recovered by default",
+			expect: func() bool {
+				return assert.Panics(t, func() { _ = newRecoverTestTagger().In(1, &recoverTestUser{}, "") })
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerRecover] %s is not true", c.name))
+		}
+	}
+}
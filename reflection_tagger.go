@@ -4,21 +4,173 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 )
 
 // Tags [name] -> Tag
 type Tags map[string]*Tag
 
+// ErrFrozen returned by Register when the tagger is frozen
+var ErrFrozen = errors.New("tagger is frozen, tags can't be registered")
+
+// registryState shared state of the tagger (copies of the tagger are made for every In/Out call)
+type registryState struct {
+	mu     sync.RWMutex
+	frozen bool
+}
+
+// ReflectionTagger walks over structs by reflection (or by the generated functions of the types)
 type ReflectionTagger struct {
+	// Tags are never changed after registration: a new map is created for every registered tag,
+	// so In/Out work with the tags which were registered before the call
 	tags Tags
+	// Position of the tag by registration: [name] -> position
+	order map[string]int
+	// Result of the validation by type (validated only once): reflect.Type -> error
+	validated *sync.Map
+	// Handlers of the fields of the generated types with the parsed field tags (prepared only once)
+	prepared *sync.Map
+	state    *registryState
+	// Tags without a handler for the current direction return an error instead of being skipped
+	strict bool
+	// Panics are returned as *PanicError
+	recover bool
+	// Default naming strategy for Field.Key
+	naming NamingStrategy
+	// Handler call wrapped by the middlewares
+	call        HandlerCall
+	middlewares []Middleware
 }
 
+// Add registers the tag. Panics when the tag can't be registered (use Register for to get an error)
 func (r *ReflectionTagger) Add(tag *Tag) Tagger {
-	r.tags[tag.Name] = tag
+	if err := r.Register(tag); err != nil {
+		panic(err)
+	}
 
 	return r
 }
 
+// Register validates definition of the tag and registers it. Safe for concurrent use with In/Out.
+// Returns ErrFrozen after Freeze and an error when a tag with the same name is already registered.
+func (r *ReflectionTagger) Register(tag *Tag) error {
+	return r.register(false, tag)
+}
+
+// Replace the same as Register, but overrides a registered tag with the same name
+func (r *ReflectionTagger) Replace(tag *Tag) error {
+	return r.register(true, tag)
+}
+
+// register tags: all or nothing
+func (r *ReflectionTagger) register(replace bool, tags ...*Tag) error {
+	for _, tag := range tags {
+		if tag == nil {
+			return errors.New("%tag% cannot be empty")
+		}
+
+		if err := tag.check(); err != nil {
+			return err
+		}
+	}
+
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	if r.state.frozen {
+		return ErrFrozen
+	}
+
+	registered := make(Tags, len(r.tags)+len(tags))
+	for name, t := range r.tags {
+		registered[name] = t
+	}
+
+	for _, tag := range tags {
+		if err := registered.add(tag, replace); err != nil {
+			return err
+		}
+	}
+
+	// Handlers of a field are called in order of registration (replaced tag keeps its place)
+	order := make(map[string]int, len(registered))
+	for name, position := range r.order {
+		order[name] = position
+	}
+
+	for _, tag := range tags {
+		if _, exists := order[tag.Name]; !exists {
+			order[tag.Name] = len(order)
+		}
+	}
+
+	r.tags = registered
+	r.order = order
+	// New tag can have options, so every type must be validated again
+	r.validated = new(sync.Map)
+	r.prepared = new(sync.Map)
+
+	return nil
+}
+
+// add the tag if its name and keys aren't used by other tags
+func (t Tags) add(tag *Tag, replace bool) error {
+	if _, exists := t[tag.Name]; exists && !replace {
+		return errors.New(fmt.Sprintf("Tag %s is already registered. Use Replace for to override it", tag.Name))
+	}
+
+	for name, registered := range t {
+		if name == tag.Name {
+			continue
+		}
+
+		for _, key := range tag.Keys() {
+			if containsInSlice(key, registered.Keys()) {
+				return errors.New(fmt.Sprintf("Key %s of the tag %s is already used by the tag %s", key, tag.Name, name))
+			}
+		}
+	}
+	t[tag.Name] = tag
+
+	return nil
+}
+
+// Freeze forbids registration of new tags
+func (r *ReflectionTagger) Freeze() {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.state.frozen = true
+}
+
+// Strict fields which have a tag without a handler for the current direction (In/Out) return an error.
+// Such tags are skipped by default, so Out-only tags don't break In and vice versa.
+func (r *ReflectionTagger) Strict() *ReflectionTagger {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.strict = true
+
+	return r
+}
+
+// IsFrozen was Freeze called
+func (r *ReflectionTagger) IsFrozen() bool {
+	r.state.mu.RLock()
+	defer r.state.mu.RUnlock()
+
+	return r.state.frozen
+}
+
+// snapshot returns copy of the tagger with the currently registered tags
+func (r *ReflectionTagger) snapshot() ReflectionTagger {
+	r.state.mu.RLock()
+	defer r.state.mu.RUnlock()
+
+	return *r
+}
+
 // Collect defined tags when passed some tags for process or use all defined
 func (r ReflectionTagger) collectCorrectTags(tagsForProcess []string) (Tags, error) {
 	tagsForWork := make(Tags)
@@ -65,9 +217,87 @@ func (r ReflectionTagger) createValueOf(value any) (reflect.Value, error) {
 	return valueOf, nil
 }
 
+// Validate tags of the fields (and nested structs) by declared options of the tags.
+// Result is cached by type, so every type is validated only once.
+func (r ReflectionTagger) validateType(typeOf reflect.Type) error {
+	if err, exists := r.validated.Load(typeOf); exists {
+		if err == nil {
+			return nil
+		}
+
+		return err.(error)
+	}
+
+	err := r.validateFields(typeOf, make(map[reflect.Type]bool))
+	r.validated.Store(typeOf, err)
+
+	return err
+}
+
+func (r ReflectionTagger) validateFields(typeOf reflect.Type, visited map[reflect.Type]bool) error {
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	if typeOf.Kind() != reflect.Struct || visited[typeOf] {
+		return nil
+	}
+	visited[typeOf] = true
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		structField := typeOf.Field(i)
+		// Options of the struct (the marker) and unexported fields aren't validated by the options of the tags
+		if !isProcessed(structField) {
+			continue
+		}
+
+		for _, tag := range r.tags {
+			if _, exists := tag.Lookup(structField.Tag); !exists || len(tag.Schema) == 0 {
+				continue
+			}
+
+			fieldTag := NewFieldTag(structField.Tag)
+			fieldTag.FieldName = structField.Name
+			tag.parse(&fieldTag)
+			if err := tag.Validate(fieldTag); err != nil {
+				return errors.New(fmt.Sprintf("%s: %s", typeOf, err))
+			}
+		}
+
+		if err := r.validateFields(structField.Type, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Collect all of a struct fields which can be processed by handlers
+func (r ReflectionTagger) fields(valueOf reflect.Value, typeOf reflect.Type, parentStruct *ParentStruct, needToSet bool) []*Field {
+	fields := r.collectFields(valueOf, typeOf, parentStruct, needToSet)
+
+	structTag := structOptionsOf(valueOf.Type())
+	for _, field := range fields {
+		field.structTag = structTag
+		field.naming = r.naming
+	}
+
+	return fields
+}
+
+// isProcessed unexported fields (and the marker of the struct options) can't be set or read by handlers,
+// so In/Out, validation of the tags and Explain skip them
+func isProcessed(structField reflect.StructField) bool {
+	return structField.IsExported()
+}
+
 // Collect all of a struct fields
 func (r ReflectionTagger) collectFields(valueOf reflect.Value, typeOf reflect.Type, parentStruct *ParentStruct, needToSet bool) (fields []*Field) {
 	for i := 0; i < valueOf.NumField(); i++ {
+		if !isProcessed(typeOf.Field(i)) {
+			continue
+		}
+
 		v := valueOf.Field(i)
 
 		// Nested struct with reference
@@ -85,6 +315,8 @@ func (r ReflectionTagger) collectFields(valueOf reflect.Value, typeOf reflect.Ty
 		}
 
 		structField := typeOf.Field(i)
+		fieldTag := NewFieldTag(structField.Tag)
+		fieldTag.FieldName = structField.Name
 
 		fields = append(fields, &Field{
 			Value:        v,
@@ -92,7 +324,7 @@ func (r ReflectionTagger) collectFields(valueOf reflect.Value, typeOf reflect.Ty
 			ParentStruct: parentStruct,
 			Index:        i,
 			IsStruct:     isStruct(v),
-			Tag:          NewFieldTag(structField.Tag),
+			Tag:          fieldTag,
 			tags:         make(Tags),
 		})
 	}
@@ -121,19 +353,35 @@ func (r ReflectionTagger) makeHandlersForField(field *Field, handlerForEmptyFiel
 	}
 
 	for name, handler := range tagsForWork {
-		_, exists := field.StructField.Tag.Lookup(name)
-		if !exists {
+		if _, exists := handler.Lookup(field.StructField.Tag); !exists {
 			continue
 		}
 
 		handlers[name] = handler
 	}
 
+	if handlerForEmptyField != nil && handlerForEmptyField.WithoutKey {
+		if _, exists := handlerForEmptyField.Lookup(field.StructField.Tag); !exists {
+			handlers[handlerForEmptyField.Name] = handlerForEmptyField
+		}
+	}
+
 	return handlers
 }
 
-func (r ReflectionTagger) In(data any, in any, tagForEmpty string, tags ...string) error {
-	return r.in(nil, data, in, tagForEmpty, tags...)
+func (r *ReflectionTagger) In(data any, in any, tagForEmpty string, tags ...string) (err error) {
+	snapshot := r.snapshot()
+	if snapshot.recover {
+		defer recoverPanic(&err, nil)
+	}
+
+	if in != nil {
+		if err = snapshot.validateType(reflect.TypeOf(in)); err != nil {
+			return err
+		}
+	}
+
+	return snapshot.in(nil, data, in, tagForEmpty, tags...)
 }
 
 func (r ReflectionTagger) in(parentStruct *ParentStruct, data any, in any, tagForEmpty string, tags ...string) error {
@@ -152,38 +400,91 @@ func (r ReflectionTagger) in(parentStruct *ParentStruct, data any, in any, tagFo
 		typeOf = typeOf.Elem()
 	}
 
-	fields := r.collectFields(valueOf, typeOf, parentStruct, true)
-
-	var handlerForEmptyField *Tag
-	if len(tagForEmpty) > 0 {
-		handlerForEmptyField, err = r.getHandlerForEmptyTag(tagForEmpty, tagsForWork)
-		if err != nil {
+	target := hookTarget(valueOf)
+	if hook, ok := target.(BeforeTaggerIn); ok {
+		if err = hook.BeforeTaggerIn(data); err != nil {
 			return err
 		}
 	}
 
-	// Evaluate
-	for i, field := range fields {
-		// TODO: Reimplement more effective
-		fields[i].tags = r.makeHandlersForField(field, handlerForEmptyField, tagsForWork)
-	}
+	structCall := structCall{parentStruct: parentStruct, valueOf: valueOf, tagForEmpty: tagForEmpty, tags: tags}
+	if generatedType, exists := findGenerated(valueOf); exists {
+		generatedStruct, err := r.generatedStruct(generatedType, structCall, tagsForWork)
+		if err != nil {
+			return err
+		}
 
-	for _, field := range fields {
-		if err = r.callInHandlers(data, field.tags, field, &valueOf); err != nil {
+		if err = generatedType.In(generatedStruct, data, valueOf.Addr().Interface()); err != nil {
+			return err
+		}
+	} else {
+		fields, err := r.fieldsWithHandlers(valueOf, typeOf, parentStruct, true, tagForEmpty, tagsForWork)
+		if err != nil {
 			return err
 		}
 
-		if field.IsStruct {
-			parentS := &ParentStruct{Value: valueOf, ParentField: field}
-			if err = r.in(parentS, data, field.Value.Interface(), tagForEmpty, tags...); err != nil {
+		for _, field := range fields {
+			if err = r.inField(structCall, data, field, r.fieldHandlers(field), false); err != nil {
 				return err
 			}
 		}
 	}
 
+	if hook, ok := target.(AfterTaggerIn); ok {
+		return hook.AfterTaggerIn(data)
+	}
+
 	return nil
 }
 
+// structCall arguments of In/Out for the fields of a struct
+type structCall struct {
+	parentStruct *ParentStruct
+	valueOf      reflect.Value
+	tagForEmpty  string
+	tags         []string
+}
+
+// inField calls In handlers of the field and fills the nested struct
+func (r ReflectionTagger) inField(structCall structCall, data any, field *Field, handlers []fieldHandler, direct bool) error {
+	if _, err := r.callHandlers(DirectionIn, data, handlers, field, &structCall.valueOf, direct); err != nil {
+		return err
+	}
+
+	// A handler can leave the nested struct empty (nil)
+	isNil := field.Value.Kind() == reflect.Ptr && field.Value.IsNil()
+	if field.IsStruct && !isNil && !field.marshaled {
+		parentS := &ParentStruct{Value: structCall.valueOf, ParentField: field}
+
+		return r.in(parentS, data, field.Value.Interface(), structCall.tagForEmpty, structCall.tags...)
+	}
+
+	return nil
+}
+
+// fieldsWithHandlers collects fields of the struct with the tags which handle them
+func (r ReflectionTagger) fieldsWithHandlers(
+	valueOf reflect.Value, typeOf reflect.Type, parentStruct *ParentStruct, needToSet bool, tagForEmpty string, tagsForWork Tags,
+) ([]*Field, error) {
+	var (
+		handlerForEmptyField *Tag
+		err                  error
+	)
+	if len(tagForEmpty) > 0 {
+		handlerForEmptyField, err = r.getHandlerForEmptyTag(tagForEmpty, tagsForWork)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := r.fields(valueOf, typeOf, parentStruct, needToSet)
+	for _, field := range fields {
+		field.tags = r.makeHandlersForField(field, handlerForEmptyField, tagsForWork)
+	}
+
+	return fields, nil
+}
+
 func (r ReflectionTagger) nestedStructWithPointerToInterfaceType(value reflect.Value) any {
 	pointer := reflect.PointerTo(value.Type().Elem())
 	isPtr := pointer.Kind() == reflect.Ptr
@@ -195,8 +496,20 @@ func (r ReflectionTagger) nestedStructWithPointerToInterfaceType(value reflect.V
 	return reflect.ValueOf(pointer).Interface()
 }
 
-func (r ReflectionTagger) Out(data any, out interface{}, tagForEmpty string, tags ...string) (output interface{}, err error) {
-	return r.out(nil, data, out, tagForEmpty, tags...)
+func (r *ReflectionTagger) Out(data any, out interface{}, tagForEmpty string, tags ...string) (output interface{}, err error) {
+	snapshot := r.snapshot()
+	if snapshot.recover {
+		output = data
+		defer recoverPanic(&err, nil)
+	}
+
+	if out != nil {
+		if err = snapshot.validateType(reflect.TypeOf(out)); err != nil {
+			return data, err
+		}
+	}
+
+	return snapshot.out(nil, data, out, tagForEmpty, tags...)
 }
 
 func (r ReflectionTagger) out(parentStruct *ParentStruct, data any, out interface{}, tagForEmpty string, tags ...string) (output interface{}, err error) {
@@ -216,56 +529,117 @@ func (r ReflectionTagger) out(parentStruct *ParentStruct, data any, out interfac
 		typeOf = typeOf.Elem()
 	}
 
-	fields := r.collectFields(valueOf, typeOf, parentStruct, false)
-
-	var handlerForEmptyField *Tag
-	if len(tagForEmpty) > 0 {
-		handlerForEmptyField, err = r.getHandlerForEmptyTag(tagForEmpty, tagsForWork)
-		if err != nil {
+	target := hookTarget(valueOf)
+	if hook, ok := target.(BeforeTaggerOut); ok {
+		if err = hook.BeforeTaggerOut(output); err != nil {
 			return
 		}
 	}
 
-	// Evaluate
-	for i, field := range fields {
-		// TODO: Reimplement more effective
-		fields[i].tags = r.makeHandlersForField(field, handlerForEmptyField, tagsForWork)
-	}
+	structCall := structCall{parentStruct: parentStruct, valueOf: valueOf, tagForEmpty: tagForEmpty, tags: tags}
+	if generatedType, exists := findGenerated(valueOf); exists {
+		var generatedStruct *GeneratedStruct
+		if generatedStruct, err = r.generatedStruct(generatedType, structCall, tagsForWork); err != nil {
+			return
+		}
 
-	for _, field := range fields {
-		if output, err = r.callOutHandlers(output, field.tags, field, &valueOf); err != nil {
+		if output, err = generatedType.Out(generatedStruct, output, valueOf.Addr().Interface()); err != nil {
+			return
+		}
+	} else {
+		var fields []*Field
+		if fields, err = r.fieldsWithHandlers(valueOf, typeOf, parentStruct, false, tagForEmpty, tagsForWork); err != nil {
 			return
 		}
 
-		if field.IsStruct {
-			parentS := &ParentStruct{Value: valueOf, ParentField: field}
-			if output, err = r.out(parentS, output, field.Value.Interface(), tagForEmpty, tags...); err != nil {
+		for _, field := range fields {
+			if output, err = r.outField(structCall, output, field, r.fieldHandlers(field), false); err != nil {
 				return
 			}
 		}
 	}
 
+	if hook, ok := target.(AfterTaggerOut); ok {
+		err = hook.AfterTaggerOut(output)
+	}
+
+	return
+}
+
+// outField calls Out handlers of the field and exports the nested struct
+func (r ReflectionTagger) outField(structCall structCall, data any, field *Field, handlers []fieldHandler, direct bool) (output any, err error) {
+	if output, err = r.callHandlers(DirectionOut, data, handlers, field, &structCall.valueOf, direct); err != nil {
+		return
+	}
+
+	// Nil nested struct doesn't have fields for Out
+	isNil := field.Value.Kind() == reflect.Ptr && field.Value.IsNil()
+	if field.IsStruct && !isNil && !field.marshaled {
+		parentS := &ParentStruct{Value: structCall.valueOf, ParentField: field}
+
+		return r.out(parentS, output, field.Value.Interface(), structCall.tagForEmpty, structCall.tags...)
+	}
+
 	return
 }
 
-func (r ReflectionTagger) callOutHandlers(data any, tags Tags, field *Field, in *reflect.Value) (output interface{}, err error) {
+// fieldHandler handler of a field with the field tag parsed by the symbols of the tag
+type fieldHandler struct {
+	tag           *Tag
+	fieldTag      FieldTag
+	structOptions FieldTag
+}
+
+// fieldHandlers parses tags of the field for its handlers (in order of registration)
+func (r ReflectionTagger) fieldHandlers(field *Field) []fieldHandler {
+	handlers := make([]fieldHandler, 0, len(field.tags))
+	for _, name := range r.ordered(field.tags) {
+		handler := fieldHandler{tag: field.tags[name], fieldTag: field.Tag}
+		handler.tag.prepare(&handler.fieldTag)
+		handler.structOptions = handler.tag.structOptions(field.structTag)
+		handlers = append(handlers, handler)
+	}
+
+	return handlers
+}
+
+// callHandlers calls the handlers of the field. direct calls the handlers of the tags without middlewares
+// and marshalers (the field type doesn't implement them).
+func (r ReflectionTagger) callHandlers(
+	direction Direction, data any, handlers []fieldHandler, field *Field, in *reflect.Value, direct bool,
+) (output interface{}, err error) {
 	output = data
-	for _, handler := range tags {
-		if handler.OutHandlerF == nil && handler.OutHandlerC == nil {
-			return output, errors.New("empty")
+	for _, handler := range handlers {
+		supported := handler.tag.SupportsIn()
+		if direction == DirectionOut {
+			supported = handler.tag.SupportsOut()
 		}
 
-		field.Tag.TagSymbols = handler.TagSymbols
-		field.Tag.ParsedTags = field.Tag.TagsToParsed()
-		if handler.OutHandlerF != nil {
-			if output, err = handler.OutHandlerF(data, field, in); err != nil {
-				return
+		if !supported {
+			if r.strict {
+				return output, errors.New(fmt.Sprintf(
+					"Tag %s doesn't have a handler for %s (field %s)", handler.tag.Name, direction, field.Name(),
+				))
 			}
 
 			continue
 		}
 
-		if output, err = handler.OutHandlerC.Handle(data, field, in); err != nil {
+		field.Tag = handler.fieldTag
+		field.structOptions = handler.structOptions
+		call := Call{Direction: direction, Tag: handler.tag, Field: field, Data: data, Struct: in}
+		if direction == DirectionOut {
+			// Out handlers of the field are chained by the output
+			call.Data = output
+		}
+
+		if direct {
+			output, err = handle(call)
+		} else {
+			output, err = r.invoke(call)
+		}
+
+		if err != nil {
 			return
 		}
 	}
@@ -273,32 +647,40 @@ func (r ReflectionTagger) callOutHandlers(data any, tags Tags, field *Field, in
 	return
 }
 
-func (r ReflectionTagger) callInHandlers(data any, tags Tags, field *Field, in *reflect.Value) error {
-	for _, handler := range tags {
-		if handler.InHandlerF == nil && handler.InHandlerC == nil {
-			return errors.New("empty")
-		}
-
-		field.Tag.TagSymbols = handler.TagSymbols
-		field.Tag.ParsedTags = field.Tag.TagsToParsed()
-		if handler.InHandlerF != nil {
-			if err := handler.InHandlerF(data, field, in); err != nil {
-				return err
-			}
+// ordered returns names of the tags in order of registration
+func (r ReflectionTagger) ordered(tags Tags) []string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return r.order[names[i]] < r.order[names[j]]
+	})
 
-			continue
-		}
+	return names
+}
 
-		if err := handler.InHandlerC.Handle(data, field, in); err != nil {
-			return err
-		}
+func (r ReflectionTagger) invoke(call Call) (output any, err error) {
+	output = call.Data
+	if r.recover {
+		defer recoverPanic(&err, &call)
 	}
 
-	return nil
+	return r.call(call)
 }
 
-func NewReflectionTagger() Tagger {
+var (
+	_ Tagger    = (*ReflectionTagger)(nil)
+	_ Registrar = (*ReflectionTagger)(nil)
+	_ Explainer = (*ReflectionTagger)(nil)
+)
+
+func NewReflectionTagger() *ReflectionTagger {
 	return &ReflectionTagger{
-		tags: make(map[string]*Tag),
+		tags:      make(map[string]*Tag),
+		validated: new(sync.Map),
+		prepared:  new(sync.Map),
+		state:     new(registryState),
+		call:      callHandler,
 	}
 }
@@ -0,0 +1,483 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type registryTestUser struct {
+	Username string `reg_in:"username" reg_out:"username"`
+	ID       int    `reg_in:"id" reg_out:"id" reg_options:"limit:10"`
+}
+
+func newRegistryTestTagger() *ReflectionTagger {
+	tagger := NewReflectionTagger()
+	tagger.
+		Add(New("reg_in").InFunction(func(data any, field *Field, in *reflect.Value) error {
+			if field.Type() == reflect.Int {
+				return field.Set(data.(int))
+			}
+
+			return field.Set(fmt.Sprint(data))
+		})).
+		Add(New("reg_out").OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			return append(data.([]string), fmt.Sprint(field.Get())), nil
+		}))
+
+	return tagger
+}
+
+type registryInContract struct{}
+
+func (registryInContract) Handle(data any, field *Field, in *reflect.Value) error {
+	return nil
+}
+
+type registryOutContract struct{}
+
+func (registryOutContract) Handle(data any, field *Field, in *reflect.Value) (any, error) {
+	return data, nil
+}
+
+func TestReflectionTaggerRegister(t *testing.T) {
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test registration after freeze",
+			expect: func() bool {
+				tagger := newRegistryTestTagger()
+				tagger.Freeze()
+				err := tagger.Register(New("reg_options").InFunction(func(data any, field *Field, in *reflect.Value) error {
+					return nil
+				}))
+
+				return assert.True(t, errors.Is(err, ErrFrozen)) &&
+					assert.Panics(t, func() { tagger.Add(New("reg_options")) }) &&
+					assert.Nil(t, tagger.In(1, &registryTestUser{}, "", "reg_in"))
+			},
+		},
+		{
+			name: "Test validation cache is reset by registration",
+			expect: func() bool {
+				tagger := newRegistryTestTagger()
+				user := &registryTestUser{}
+				if !assert.Nil(t, tagger.In(1, user, "", "reg_in")) {
+					return false
+				}
+
+				err := tagger.Register(New("reg_options").
+					Options(Opt("limit").Int().Required(), Opt("offset").Required()).
+					InFunction(func(data any, field *Field, in *reflect.Value) error {
+						return nil
+					}))
+
+				return assert.Nil(t, err) &&
+					assert.Error(t, tagger.In(1, user, "", "reg_in"))
+			},
+		},
+		{
+			name: "Test registration of the same tag",
+			expect: func() bool {
+				tagger := newRegistryTestTagger()
+				replaced := New("reg_in").InFunction(func(data any, field *Field, in *reflect.Value) error {
+					return field.Set("replaced")
+				})
+				err := tagger.Register(replaced)
+				user := &registryTestUser{}
+
+				return assert.EqualError(t, err, "Tag reg_in is already registered. Use Replace for to override it") &&
+					assert.Panics(t, func() { tagger.Add(replaced) }) &&
+					assert.Nil(t, tagger.Replace(replaced)) &&
+					assert.Error(t, tagger.In(nil, user, "", "reg_in"))
+			},
+		},
+		{
+			name: "Test definition of the tag",
+			expect: func() bool {
+				tagger := newRegistryTestTagger()
+				in := func(data any, field *Field, in *reflect.Value) error { return nil }
+				out := func(data any, field *Field, in *reflect.Value) (any, error) { return data, nil }
+				errs := []error{
+					tagger.Register(nil),
+					tagger.Register(New("").InFunction(in)),
+					tagger.Register(New("reg tag").InFunction(in)),
+					tagger.Register(New("reg_empty")),
+					tagger.Register(New("reg_both").InFunction(in).InContract(registryInContract{})),
+					tagger.Register(New("reg_both").OutFunction(out).OutContract(registryOutContract{})),
+					tagger.Register(New("reg_self").InFunction(in).ConflictsWith("reg_self")),
+					tagger.Register(New("reg_opt").InFunction(in).Options(Opt("key"), Opt("KEY"))),
+					tagger.Register(New("reg_opt").InFunction(in).Options(Opt("limit").Int().Default("ten"))),
+					tagger.Register(New("reg_alias").InFunction(in).Aliases("reg_alias")),
+					tagger.Register(New("reg_alias").InFunction(in).Aliases("reg_api").Prefer("reg_other")),
+					tagger.Register(New("reg_alias").InFunction(in).Aliases("reg_in")),
+				}
+
+				for _, err := range errs {
+					if !assert.Error(t, err) {
+						return false
+					}
+				}
+
+				return assert.EqualError(t, errs[3], "Tag reg_empty doesn't have handlers for In or Out") &&
+					assert.Nil(t, tagger.Register(New("reg_opt").InFunction(in).Options(Opt("limit").Int().Default("10")))) &&
+					assert.Nil(t, tagger.Register(New("reg_out_only").OutFunction(out)))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerRegister] %s is not true", c.name))
+		}
+	}
+}
+
+// Must be run with -race
+func TestReflectionTaggerConcurrentUsage(t *testing.T) {
+	tagger := newRegistryTestTagger()
+	noop := func(data any, field *Field, in *reflect.Value) error {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 300)
+	for i := 0; i < 100; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			user := &registryTestUser{}
+			if err := tagger.In(i, user, "", "reg_in"); err != nil {
+				errs <- err
+			} else if user.ID != i {
+				errs <- errors.New(fmt.Sprintf("expected %d, actual %d", i, user.ID))
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			output, err := tagger.Out([]string{}, &registryTestUser{Username: "user", ID: i}, "", "reg_out")
+			if err != nil {
+				errs <- err
+			} else if !reflect.DeepEqual(output, []string{"user", fmt.Sprint(i)}) {
+				errs <- errors.New(fmt.Sprintf("unexpected output %v", output))
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			if err := tagger.Register(New(fmt.Sprintf("reg_extra_%d", i)).InFunction(noop)); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(fmt.Sprintf("[TestReflectionTaggerConcurrentUsage] %s", err))
+	}
+
+	tagger.Freeze()
+	if err := tagger.Register(New("reg_extra").InFunction(noop)); !errors.Is(err, ErrFrozen) {
+		t.Error("[TestReflectionTaggerConcurrentUsage] Registration after freeze is not true")
+	}
+}
+
+func TestReflectionTaggerDirection(t *testing.T) {
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test tags without a handler for the direction are skipped",
+			expect: func() bool {
+				tagger := newRegistryTestTagger()
+				user := &registryTestUser{}
+				err := tagger.In(1, user, "")
+				output, outErr := tagger.Out([]string{}, &registryTestUser{Username: "user", ID: 2}, "")
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, 1, user.ID) &&
+					assert.Nil(t, outErr) &&
+					assert.Equal(t, []string{"user", "2"}, output)
+			},
+		},
+		{
+			name: "Test strict mode",
+			expect: func() bool {
+				tagger := newRegistryTestTagger().Strict()
+				_, outErr := tagger.Out([]string{}, &registryTestUser{}, "")
+
+				return assert.EqualError(t, tagger.In(1, &registryTestUser{}, ""), "Tag reg_out doesn't have a handler for In (field Username)") &&
+					assert.EqualError(t, outErr, "Tag reg_in doesn't have a handler for Out (field Username)") &&
+					assert.Nil(t, tagger.In(1, &registryTestUser{}, "", "reg_in"))
+			},
+		},
+		{
+			name: "Test tag supports direction",
+			expect: func() bool {
+				in := New("reg_in").InFunction(func(data any, field *Field, in *reflect.Value) error { return nil })
+
+				return assert.True(t, in.SupportsIn()) &&
+					assert.False(t, in.SupportsOut()) &&
+					assert.True(t, New("reg_out").OutContract(registryOutContract{}).SupportsOut())
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerDirection] %s is not true", c.name))
+		}
+	}
+}
+
+type orderTestUser struct {
+	Name string `a:"" b:"" c:""`
+}
+
+func TestReflectionTaggerHandlersOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) *Tag {
+		return New(name).InFunction(func(data any, field *Field, in *reflect.Value) error {
+			calls = append(calls, name)
+
+			return nil
+		})
+	}
+
+	tagger := NewReflectionTagger()
+	tagger.Add(record("c")).Add(record("a")).Add(record("b"))
+	for i := 0; i < 10; i++ {
+		calls = nil
+		if !assert.Nil(t, tagger.In(nil, &orderTestUser{}, "")) || !assert.Equal(t, []string{"c", "a", "b"}, calls) {
+			t.Error("[TestReflectionTaggerHandlersOrder] Test handlers are called in order of registration is not true")
+
+			return
+		}
+	}
+
+	calls = nil
+	if !assert.Nil(t, tagger.Replace(record("c"))) ||
+		!assert.Nil(t, tagger.In(nil, &orderTestUser{}, "")) ||
+		!assert.Equal(t, []string{"c", "a", "b"}, calls) {
+		t.Error("[TestReflectionTaggerHandlersOrder] Test replaced tag keeps its place is not true")
+	}
+
+	calls = nil
+	if !assert.Nil(t, tagger.In(nil, &orderTestUser{}, "", "b", "c")) || !assert.Equal(t, []string{"c", "b"}, calls) {
+		t.Error("[TestReflectionTaggerHandlersOrder] Test tags for process are called in order of registration is not true")
+	}
+
+	add := func(name string, n int) *Tag {
+		return New(name).OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			return data.(int) + n, nil
+		})
+	}
+	chained := NewReflectionTagger()
+	chained.Add(add("a", 1)).Add(add("b", 10))
+	output, err := chained.Out(0, &orderTestUser{}, "")
+	if !assert.Nil(t, err) || !assert.Equal(t, 11, output) {
+		t.Error("[TestReflectionTaggerHandlersOrder] Test out handlers of the field get the output of the previous handler is not true")
+	}
+}
+
+type unexportedTestState struct {
+	Value string `unexp:"kye:value"`
+}
+
+type unexportedTestUser struct {
+	Name    string               `unexp:"key:name"`
+	secret  string               `unexp:"unknown:value"`
+	state   *unexportedTestState `unexp:"key:state"`
+	profile unexportedTestState
+}
+
+func TestReflectionTaggerUnexportedFields(t *testing.T) {
+	tagger := NewReflectionTagger()
+	tagger.Add(New("unexp").
+		Symbols(":", " | ").
+		Options(Opt("key")).
+		InFunction(func(data any, field *Field, in *reflect.Value) error {
+			return field.Set(data.(string))
+		}).
+		OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			return append(data.([]string), field.Path()), nil
+		}),
+	)
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test unexported fields aren't filled and their tags aren't validated",
+			expect: func() bool {
+				user := unexportedTestUser{}
+
+				return assert.Nil(t, tagger.In("x", &user, "")) &&
+					assert.Equal(t, "x", user.Name) &&
+					assert.Empty(t, user.secret) &&
+					assert.Nil(t, user.state) &&
+					assert.Empty(t, user.profile.Value)
+			},
+		},
+		{
+			name: "Test unexported fields aren't exported",
+			expect: func() bool {
+				output, err := tagger.Out([]string{}, &unexportedTestUser{state: &unexportedTestState{}}, "")
+
+				return assert.Nil(t, err) && assert.Equal(t, []string{"Name"}, output)
+			},
+		},
+		{
+			name: "Test unexported fields aren't explained",
+			expect: func() bool {
+				explanation, err := tagger.Explain(reflect.TypeOf(unexportedTestUser{}), ExplainOptions{})
+
+				return assert.Nil(t, err) &&
+					assert.Len(t, explanation.Fields, 1) &&
+					assert.Equal(t, "Name", explanation.Fields[0].Path) &&
+					assert.Empty(t, explanation.Fields[0].Tags[0].Error)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerUnexportedFields] %s is not true", c.name))
+		}
+	}
+}
+
+type nilTestProfile struct {
+	Email string `nil_tag:"email"`
+}
+
+type nilTestUser struct {
+	Name    string          `nil_tag:"name"`
+	Profile *nilTestProfile `nil_tag:"profile"`
+}
+
+func TestReflectionTaggerNilNestedStructs(t *testing.T) {
+	var calls []string
+	tagger := NewReflectionTagger()
+	tagger.Add(New("nil_tag").
+		InFunction(func(data any, field *Field, in *reflect.Value) error {
+			calls = append(calls, field.Path())
+			// The handler leaves the nested struct empty
+			if field.IsStruct {
+				return field.Set((*nilTestProfile)(nil))
+			}
+
+			return nil
+		}).
+		OutFunction(func(data any, field *Field, in *reflect.Value) (any, error) {
+			calls = append(calls, field.Path())
+
+			return data, nil
+		}),
+	)
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test In doesn't process fields of the nested struct which is left nil",
+			expect: func() bool {
+				calls = nil
+				user := nilTestUser{}
+
+				return assert.Nil(t, tagger.In(nil, &user, "")) &&
+					assert.Nil(t, user.Profile) &&
+					assert.Equal(t, []string{"Name", "Profile"}, calls)
+			},
+		},
+		{
+			name: "Test Out doesn't process fields of the nil nested struct",
+			expect: func() bool {
+				calls = nil
+				_, err := tagger.Out(nil, &nilTestUser{}, "")
+
+				return assert.Nil(t, err) && assert.Equal(t, []string{"Name", "Profile"}, calls)
+			},
+		},
+		{
+			name: "Test Out processes fields of the nested struct",
+			expect: func() bool {
+				calls = nil
+				_, err := tagger.Out(nil, &nilTestUser{Profile: &nilTestProfile{}}, "")
+
+				return assert.Nil(t, err) && assert.Equal(t, []string{"Name", "Profile", "Profile.Email"}, calls)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerNilNestedStructs] %s is not true", c.name))
+		}
+	}
+}
+
+type withoutKeyTestUser struct {
+	Name  string `implicit:"name"`
+	Email string `other:"email"`
+	Phone string
+}
+
+func TestReflectionTaggerFieldsWithoutKey(t *testing.T) {
+	collect := func(data any, field *Field, in *reflect.Value) (any, error) {
+		return append(data.([]string), field.Tag.Name+":"+field.Path()), nil
+	}
+	newTagger := func(implicit *Tag) *ReflectionTagger {
+		tagger := NewReflectionTagger()
+		tagger.Add(implicit.OutFunction(collect))
+		tagger.Add(New("other").OutFunction(collect))
+
+		return tagger
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test the tag for empty fields handles only the fields without tags by default",
+			expect: func() bool {
+				output, err := newTagger(New("implicit")).Out([]string{}, &withoutKeyTestUser{}, "implicit")
+
+				return assert.Nil(t, err) &&
+					assert.ElementsMatch(t, []string{"implicit:Name", "other:Email", "implicit:Phone"}, output)
+			},
+		},
+		{
+			name: "Test the tag for empty fields handles the fields without its key",
+			expect: func() bool {
+				output, err := newTagger(New("implicit").ForFieldsWithoutKey()).Out([]string{}, &withoutKeyTestUser{}, "implicit")
+
+				return assert.Nil(t, err) &&
+					assert.ElementsMatch(t, []string{"implicit:Name", "other:Email", "implicit:Email", "implicit:Phone"}, output)
+			},
+		},
+		{
+			name: "Test the tag handles the fields without its key only as the tag for empty fields",
+			expect: func() bool {
+				output, err := newTagger(New("implicit").ForFieldsWithoutKey()).Out([]string{}, &withoutKeyTestUser{}, "")
+
+				return assert.Nil(t, err) && assert.ElementsMatch(t, []string{"implicit:Name", "other:Email"}, output)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerFieldsWithoutKey] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Registry named set of tags (tags of a team, a service or a library).
+// Tags of the registry are registered in a tagger under the namespace: "billing" + "json" -> "billing.json",
+// so tags with the same names from different registries don't collide.
+// Aliases of the tags are not prefixed, so a tag can still answer to a common key of the struct tags.
+//
+//	billing := NewRegistry("billing", New("json").InFunction(...))
+//	tagger := NewReflectionTagger()
+//	tagger.Include(billing)
+//
+//	type Invoice struct {
+//	  ID uint `billing.json:"id"`
+//	}
+type Registry struct {
+	Name string
+	Tags []*Tag
+}
+
+// NewRegistry initialize of a registry
+func NewRegistry(name string, tags ...*Tag) *Registry {
+	return &Registry{
+		Name: name,
+		Tags: tags,
+	}
+}
+
+// Add tag to the registry
+func (r *Registry) Add(tag *Tag) *Registry {
+	r.Tags = append(r.Tags, tag)
+
+	return r
+}
+
+// Qualified returns name of the tag in the namespace of the registry
+func (r *Registry) Qualified(name string) string {
+	if len(r.Name) == 0 {
+		return name
+	}
+
+	return r.Name + "." + name
+}
+
+// Include registers tags of the registries under their namespaces.
+// Nothing is registered when any of the tags can't be registered.
+func (r *ReflectionTagger) Include(registries ...*Registry) error {
+	var tags []*Tag
+	for _, registry := range registries {
+		if registry == nil {
+			return errors.New("%registry% cannot be empty")
+		}
+
+		for _, tag := range registry.Tags {
+			if tag == nil {
+				return errors.New(fmt.Sprintf("Registry %s has an empty tag", registry.Name))
+			}
+
+			qualified := *tag
+			qualified.Name = registry.Qualified(tag.Name)
+			qualified.Preferred = make([]string, 0, len(tag.Preferred))
+			for _, key := range tag.Preferred {
+				if key == tag.Name {
+					key = qualified.Name
+				}
+
+				qualified.Preferred = append(qualified.Preferred, key)
+			}
+			tags = append(tags, &qualified)
+		}
+	}
+
+	return r.register(false, tags...)
+}
+
+// Tags returns registered tags
+func (r *ReflectionTagger) Tags() Tags {
+	tags := make(Tags)
+	for name, tag := range r.snapshot().tags {
+		tags[name] = tag
+	}
+
+	return tags
+}
+
+// Names returns names of the registered tags in order of registration
+func (r *ReflectionTagger) Names() []string {
+	snapshot := r.snapshot()
+
+	return snapshot.ordered(snapshot.tags)
+}
+
+// Clone returns an independent copy of the tagger (with the same frozen and strict state).
+// Registration in the copy doesn't change the tagger and vice versa.
+func (r *ReflectionTagger) Clone() *ReflectionTagger {
+	clone := r.snapshot()
+	clone.state = &registryState{frozen: r.IsFrozen()}
+
+	return &clone
+}
+
+// Derive returns an independent copy of the tagger which isn't frozen,
+// so a request-scoped variant of the frozen global tagger can add or override tags
+//
+//	requestTagger := globalTagger.Derive()
+//	requestTagger.Replace(New("my_json").InFunction(...))
+func (r *ReflectionTagger) Derive() *ReflectionTagger {
+	derived := r.snapshot()
+	derived.state = new(registryState)
+
+	return &derived
+}
+
+// Merge registers tags of the other tagger in order of their registration.
+// Tags with the same names are overridden by the tags of the other tagger (and keep their places).
+// Nothing is registered when any of the tags can't be registered.
+func (r *ReflectionTagger) Merge(other Registrar) error {
+	if other == nil {
+		return errors.New("%other% cannot be empty")
+	}
+
+	tags := other.Tags()
+	merged := make([]*Tag, 0, len(tags))
+	for _, name := range other.Names() {
+		if tag, exists := tags[name]; exists {
+			merged = append(merged, tag)
+		}
+	}
+
+	return r.register(true, merged...)
+}
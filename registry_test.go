@@ -0,0 +1,237 @@
+package tagger
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type registryTestAccount struct {
+	Name    string `api:"api_name" json:"json_name"`
+	Email   string `api:"api_email"`
+	Invoice string `billing.json:"invoice"`
+	Plain   string `json:"plain"`
+}
+
+// Sets key of the struct tags and the value of the tag: "api=api_name"
+func registryKeyIn(data any, field *Field, in *reflect.Value) error {
+	return field.Set(fmt.Sprintf("%s=%s", field.Tag.Name, field.Tag.Raw()))
+}
+
+func TestTagAliases(t *testing.T) {
+	cases := []struct {
+		name   string
+		tag    *Tag
+		expect func(account registryTestAccount, err error) bool
+	}{
+		{
+			name: "Test tag answers to aliases",
+			tag:  New("json").Aliases("api").InFunction(registryKeyIn),
+			expect: func(account registryTestAccount, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, "json=json_name", account.Name) &&
+					assert.Equal(t, "api=api_email", account.Email) &&
+					assert.Equal(t, "json=plain", account.Plain) &&
+					assert.Empty(t, account.Invoice)
+			},
+		},
+		{
+			name: "Test preferred alias",
+			tag:  New("json").Aliases("api").Prefer("api").InFunction(registryKeyIn),
+			expect: func(account registryTestAccount, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, "api=api_name", account.Name) &&
+					assert.Equal(t, "json=plain", account.Plain)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		account := registryTestAccount{}
+		err := NewReflectionTagger().Add(c.tag).In(nil, &account, "")
+		if !c.expect(account, err) {
+			t.Error(fmt.Sprintf("[TestTagAliases] %s is not true", c.name))
+		}
+	}
+}
+
+func TestReflectionTaggerInclude(t *testing.T) {
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test tags of the registries are registered under namespaces",
+			expect: func() bool {
+				tagger := NewReflectionTagger()
+				tagger.Add(New("json").InFunction(registryKeyIn))
+				err := tagger.Include(
+					NewRegistry("billing", New("json").InFunction(registryKeyIn)),
+					NewRegistry("crm").Add(New("json").Aliases("api").InFunction(registryKeyIn)),
+				)
+				account := registryTestAccount{}
+
+				return assert.Nil(t, err) &&
+					assert.Nil(t, tagger.In(nil, &account, "", "billing.json", "crm.json")) &&
+					assert.Equal(t, "billing.json=invoice", account.Invoice) &&
+					assert.Equal(t, "api=api_email", account.Email) &&
+					assert.Empty(t, account.Plain)
+			},
+		},
+		{
+			name: "Test nothing is registered when keys collide",
+			expect: func() bool {
+				tagger := NewReflectionTagger()
+				tagger.Add(New("json").Aliases("api").InFunction(registryKeyIn))
+				err := tagger.Include(
+					NewRegistry("billing", New("json").InFunction(registryKeyIn)),
+					NewRegistry("crm", New("json").Aliases("api").InFunction(registryKeyIn)),
+				)
+
+				return assert.EqualError(t, err, "Key api of the tag crm.json is already used by the tag json") &&
+					assert.EqualError(t, tagger.In(nil, &registryTestAccount{}, "", "billing.json"), "billing.json tag doesn't exists. Check provided arguments please.")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerInclude] %s is not true", c.name))
+		}
+	}
+}
+
+func TestReflectionTaggerDerive(t *testing.T) {
+	newTagger := func() *ReflectionTagger {
+		tagger := NewReflectionTagger()
+		tagger.Add(New("json").InFunction(registryKeyIn))
+
+		return tagger
+	}
+	upperIn := func(data any, field *Field, in *reflect.Value) error {
+		return field.Set("upper:" + field.Tag.Raw())
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test clone is independent",
+			expect: func() bool {
+				base := newTagger()
+				clone := base.Clone()
+				account := registryTestAccount{}
+
+				return assert.Nil(t, clone.Register(New("api").InFunction(registryKeyIn))) &&
+					assert.Len(t, base.Tags(), 1) &&
+					assert.Len(t, clone.Tags(), 2) &&
+					assert.Nil(t, clone.In(nil, &account, "")) &&
+					assert.Equal(t, "api=api_email", account.Email)
+			},
+		},
+		{
+			name: "Test clone keeps frozen state and derive doesn't",
+			expect: func() bool {
+				base := newTagger()
+				base.Freeze()
+				account := registryTestAccount{}
+				derived := base.Derive()
+
+				return assert.ErrorIs(t, base.Clone().Register(New("api").InFunction(registryKeyIn)), ErrFrozen) &&
+					assert.Nil(t, derived.Replace(New("json").InFunction(upperIn))) &&
+					assert.Nil(t, derived.In(nil, &account, "")) &&
+					assert.Equal(t, "upper:plain", account.Plain) &&
+					assert.Nil(t, base.In(nil, &account, "")) &&
+					assert.Equal(t, "json=plain", account.Plain)
+			},
+		},
+		{
+			name: "Test merge overrides tags with the same names",
+			expect: func() bool {
+				base := newTagger()
+				other := NewReflectionTagger()
+				other.
+					Add(New("json").InFunction(upperIn)).
+					Add(New("api").InFunction(registryKeyIn))
+				account := registryTestAccount{}
+
+				return assert.Nil(t, base.Merge(other)) &&
+					assert.Len(t, base.Tags(), 2) &&
+					assert.Nil(t, base.In(nil, &account, "")) &&
+					assert.Equal(t, "upper:plain", account.Plain) &&
+					assert.Equal(t, "api=api_email", account.Email)
+			},
+		},
+		{
+			name: "Test merge of colliding keys",
+			expect: func() bool {
+				base := newTagger()
+				other := NewReflectionTagger()
+				other.Add(New("api").Aliases("json").InFunction(registryKeyIn))
+
+				return assert.EqualError(t, base.Merge(other), "Key json of the tag api is already used by the tag json") &&
+					assert.Len(t, base.Tags(), 1)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerDerive] %s is not true", c.name))
+		}
+	}
+}
+
+func TestReflectionTaggerMergeOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) *Tag {
+		return New(name).InFunction(func(data any, field *Field, in *reflect.Value) error {
+			calls = append(calls, name)
+
+			return nil
+		})
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test tags of the other tagger are merged in order of their registration",
+			expect: func() bool {
+				base := NewReflectionTagger()
+				other := NewReflectionTagger()
+				other.Add(record("c")).Add(record("a")).Add(record("b"))
+				calls = nil
+
+				return assert.Nil(t, base.Merge(other)) &&
+					assert.Equal(t, []string{"c", "a", "b"}, base.Names()) &&
+					assert.Nil(t, base.In(nil, &orderTestUser{}, "")) &&
+					assert.Equal(t, []string{"c", "a", "b"}, calls)
+			},
+		},
+		{
+			name: "Test overridden tags keep their places",
+			expect: func() bool {
+				base := NewReflectionTagger()
+				base.Add(record("b")).Add(record("c"))
+				other := NewReflectionTagger()
+				other.Add(record("c")).Add(record("a"))
+				calls = nil
+
+				return assert.Nil(t, base.Merge(other)) &&
+					assert.Equal(t, []string{"b", "c", "a"}, base.Names()) &&
+					assert.Nil(t, base.In(nil, &orderTestUser{}, "")) &&
+					assert.Equal(t, []string{"b", "c", "a"}, calls)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestReflectionTaggerMergeOrder] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package tagger
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structMarker name of the field which tags are the options of the whole struct
+//
+//	type User struct {
+//	  _     struct{} `my_json:"prefix:user_"`
+//	  Email string   `my_json:"email"`
+//	}
+const structMarker = "_"
+
+var (
+	// registered options by struct type (reflect.Type -> reflect.StructTag)
+	typeOptions sync.Map
+	// options of the struct type collected from the marker fields and the registered options
+	structOptionsCache sync.Map
+)
+
+// RegisterTypeOptions registers options of the whole struct for the tags (the same as tags of a marker field).
+// Registered options are used before options of the marker field. Safe for concurrent use.
+//
+//	tagger.RegisterTypeOptions((*User)(nil), `my_json:"prefix:user_" my_logger:"skip"`)
+func RegisterTypeOptions(value any, options reflect.StructTag) {
+	typeOf := reflect.TypeOf(value)
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	typeOptions.Store(typeOf, options)
+	structOptionsCache.Delete(typeOf)
+}
+
+// structOptionsOf returns options of the struct type for all tags
+func structOptionsOf(typeOf reflect.Type) reflect.StructTag {
+	if options, exists := structOptionsCache.Load(typeOf); exists {
+		return options.(reflect.StructTag)
+	}
+
+	var options reflect.StructTag
+	if registered, exists := typeOptions.Load(typeOf); exists {
+		options = registered.(reflect.StructTag)
+	}
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		structField := typeOf.Field(i)
+		if structField.Name != structMarker || len(structField.Tag) == 0 {
+			continue
+		}
+
+		if len(options) != 0 {
+			options += " "
+		}
+		options += structField.Tag
+	}
+	structOptionsCache.Store(typeOf, options)
+
+	return options
+}
+
+// structOptions options of the struct parsed by the symbols of the tag
+func (t *Tag) structOptions(structTag reflect.StructTag) FieldTag {
+	options := FieldTag{StructTag: structTag}
+	t.parse(&options)
+
+	return options
+}
+
+// StructOptions returns options of the struct which contains the field for the processed tag.
+// Options are parsed by symbols of the tag, so typed accessors can be used:
+//
+//	prefix, exists := field.StructOptions().FindByKey("prefix")
+//	omitEmpty, err := field.StructOptions().Bool("omitempty")
+func (f Field) StructOptions() FieldTag {
+	return f.structOptions
+}
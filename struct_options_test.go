@@ -0,0 +1,76 @@
+package tagger
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type structOptionsTestProfile struct {
+	_     struct{} `opt:"prefix:profile_ | upper"`
+	Email string   `opt:"email"`
+}
+
+type structOptionsTestUser struct {
+	_       struct{}                 `opt:"prefix:user_"`
+	Name    string                   `opt:"name"`
+	Profile structOptionsTestProfile `opt:"profile"`
+}
+
+type structOptionsTestRegistered struct {
+	Name string `opt:"name"`
+}
+
+func TestStructOptions(t *testing.T) {
+	RegisterTypeOptions((*structOptionsTestRegistered)(nil), `opt:"prefix:registered_"`)
+	tagger := NewReflectionTagger().Add(New("opt").
+		Symbols(":", " | ").
+		Options(Opt("name"), Opt("email"), Opt("profile")).
+		InFunction(func(data any, field *Field, in *reflect.Value) error {
+			if field.IsStruct {
+				return nil
+			}
+
+			options := field.StructOptions()
+			upper, err := options.Bool("upper")
+			if err != nil {
+				return err
+			}
+
+			prefix, _ := options.FindByKey("prefix")
+
+			return field.Set(fmt.Sprintf("%s%s (upper: %t)", prefix, field.Tag.Raw(), upper))
+		}))
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test options of the marker field",
+			expect: func() bool {
+				user := &structOptionsTestUser{}
+
+				return assert.Nil(t, tagger.In(nil, user, "")) &&
+					assert.Equal(t, "user_name (upper: false)", user.Name) &&
+					assert.Equal(t, "profile_email (upper: true)", user.Profile.Email)
+			},
+		},
+		{
+			name: "Test registered options of the type",
+			expect: func() bool {
+				registered := &structOptionsTestRegistered{}
+
+				return assert.Nil(t, tagger.In(nil, registered, "")) &&
+					assert.Equal(t, "registered_name (upper: false)", registered.Name)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestStructOptions] %s is not true", c.name))
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package tagger
 
-import "reflect"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 type TagSymbols struct {
 	// key:value (: - symbol)
@@ -30,6 +35,23 @@ type OutHandlerC interface {
 type Tag struct {
 	Name       string
 	TagSymbols TagSymbols
+	// Keys of the tag are case-sensitive (lower-cased otherwise)
+	CaseSensitive bool
+	// Accepted keys. Any keys are accepted if it's empty
+	Schema []*Option
+	// Kinds of the fields which can have the tag (kind of the element for pointers). Any kinds if it's empty.
+	// Checked by the analyzer (tagger/tools/analysis), not by In/Out
+	Kinds []reflect.Kind
+	// Names of the tags which can't be defined on the same field. Checked by the analyzer, not by In/Out
+	Conflicts []string
+	// Other keys of the struct tags which the tag answers to: `api:"user_id"` for New("json").Aliases("api")
+	AliasNames []string
+	// Keys which are used first when several keys of the tag are defined on the same field
+	Preferred []string
+	// Derives keys of the fields without names in the tag (see Field.Key)
+	NamingStrategy NamingStrategy
+	// Used as the tag for empty fields, the tag handles the fields which don't have its key too
+	WithoutKey bool
 
 	InHandlerF InHandlerF
 	InHandlerC InHandlerC
@@ -107,6 +129,200 @@ func (t *Tag) Symbols(keyValue, keysSeparator string) *Tag {
 	return t
 }
 
+// CaseSensitiveKeys keys of the tag will be compared as is
+//   New("my_header").CaseSensitiveKeys() // my_header:"X-Request-ID:id"
+func (t *Tag) CaseSensitiveKeys() *Tag {
+	t.CaseSensitive = true
+
+	return t
+}
+
+// Options declare accepted keys of the tag.
+// Every field with the tag is validated when a struct is processed first time.
+//   New("my_logger").Options(Opt("key").Required(), Opt("to").OneOf("string", "int"))
+func (t *Tag) Options(options ...*Option) *Tag {
+	t.Schema = options
+
+	return t
+}
+
+// ForKinds the tag can be defined only on the fields of kinds (reported by the analyzer)
+//   New("my_header").ForKinds(reflect.String, reflect.Slice)
+func (t *Tag) ForKinds(kinds ...reflect.Kind) *Tag {
+	t.Kinds = kinds
+
+	return t
+}
+
+// ConflictsWith the tag can't be defined on the same field with the tags (reported by the analyzer)
+//   New("query").ConflictsWith("header", "form")
+func (t *Tag) ConflictsWith(names ...string) *Tag {
+	t.Conflicts = names
+
+	return t
+}
+
+// Aliases the tag answers to other keys of the struct tags too
+//   New("json").Aliases("my_json", "api")
+func (t *Tag) Aliases(names ...string) *Tag {
+	t.AliasNames = append(t.AliasNames, names...)
+
+	return t
+}
+
+// Prefer keys which are used when several keys of the tag are defined on the same field.
+// The name of the tag and then aliases in the declared order are used by default
+//   New("json").Aliases("api").Prefer("api")
+func (t *Tag) Prefer(keys ...string) *Tag {
+	t.Preferred = keys
+
+	return t
+}
+
+// Naming sets the naming strategy for the fields without names in the tag (overrides the strategy of the tagger)
+//   New("json").Naming(SnakeCase)
+func (t *Tag) Naming(strategy NamingStrategy) *Tag {
+	t.NamingStrategy = strategy
+
+	return t
+}
+
+// ForFieldsWithoutKey used as the tag for empty fields, the tag handles the fields which don't have its key
+// (not only the fields without any tags)
+//   New("json").ForFieldsWithoutKey() // Name string `validate:"required"` is handled by "json"
+func (t *Tag) ForFieldsWithoutKey() *Tag {
+	t.WithoutKey = true
+
+	return t
+}
+
+// Keys of the struct tags which the tag answers to in order of precedence
+func (t *Tag) Keys() []string {
+	keys := append([]string{}, t.Preferred...)
+	for _, key := range append([]string{t.Name}, t.AliasNames...) {
+		if !containsInSlice(key, t.Preferred) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// Lookup returns the key of the tag defined in the struct tags (by precedence)
+func (t *Tag) Lookup(structTag reflect.StructTag) (string, bool) {
+	for _, key := range t.Keys() {
+		if _, exists := structTag.Lookup(key); exists {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// SupportsIn does the tag have a handler for In
+func (t *Tag) SupportsIn() bool {
+	return t.InHandlerF != nil || t.InHandlerC != nil
+}
+
+// SupportsOut does the tag have a handler for Out
+func (t *Tag) SupportsOut() bool {
+	return t.OutHandlerF != nil || t.OutHandlerC != nil
+}
+
+// parse field tag by symbols of the tag
+func (t *Tag) parse(fieldTag *FieldTag) {
+	fieldTag.Name = t.Name
+	if key, exists := t.Lookup(fieldTag.StructTag); exists {
+		fieldTag.Name = key
+	}
+	fieldTag.TagSymbols = t.TagSymbols
+	fieldTag.CaseSensitive = t.CaseSensitive
+	fieldTag.ListKeys = t.listKeys()
+	fieldTag.Parse()
+}
+
+// listKeys returns names of the options declared as Multiple
+func (t *Tag) listKeys() (keys []string) {
+	for _, option := range t.Schema {
+		if option.IsMultiple {
+			keys = append(keys, option.Name)
+		}
+	}
+
+	return
+}
+
+// prepare field tag for a handler of the tag
+func (t *Tag) prepare(fieldTag *FieldTag) {
+	t.parse(fieldTag)
+	t.applyDefaults(fieldTag)
+}
+
+// check definition of the tag before registration
+func (t *Tag) check() error {
+	if len(t.Name) == 0 {
+		return errors.New("Name of the tag cannot be empty")
+	}
+
+	keys := make(map[string]bool)
+	for _, key := range append([]string{t.Name}, t.AliasNames...) {
+		if len(key) == 0 || strings.ContainsAny(key, " :\"\t\n") {
+			return errors.New(fmt.Sprintf("Name of the tag %q cannot be empty or contain spaces, quotes or colons", key))
+		}
+
+		if keys[key] {
+			return errors.New(fmt.Sprintf("Tag %s has the key %s defined more than once", t.Name, key))
+		}
+		keys[key] = true
+	}
+
+	for _, key := range t.Preferred {
+		if !keys[key] {
+			return errors.New(fmt.Sprintf("Tag %s doesn't have the preferred key %s", t.Name, key))
+		}
+	}
+
+	if t.InHandlerF != nil && t.InHandlerC != nil {
+		return errors.New(fmt.Sprintf("Tag %s has both InHandlerF and InHandlerC. Only one can be defined", t.Name))
+	}
+
+	if t.OutHandlerF != nil && t.OutHandlerC != nil {
+		return errors.New(fmt.Sprintf("Tag %s has both OutHandlerF and OutHandlerC. Only one can be defined", t.Name))
+	}
+
+	if !t.SupportsIn() && !t.SupportsOut() {
+		return errors.New(fmt.Sprintf("Tag %s doesn't have handlers for In or Out", t.Name))
+	}
+
+	for _, name := range t.Conflicts {
+		if name == t.Name {
+			return errors.New(fmt.Sprintf("Tag %s can't conflict with itself", t.Name))
+		}
+	}
+
+	fieldTag := FieldTag{Name: t.Name, TagSymbols: t.TagSymbols, CaseSensitive: t.CaseSensitive}
+	declared := make(map[string]bool)
+	for _, option := range t.Schema {
+		key := fieldTag.normalizeKey(option.Name)
+		if len(key) == 0 {
+			return errors.New(fmt.Sprintf("Tag %s has an option with empty name", t.Name))
+		}
+
+		if declared[key] {
+			return errors.New(fmt.Sprintf("Tag %s has the option %s declared more than once", t.Name, option.Name))
+		}
+		declared[key] = true
+
+		if option.HasDefault {
+			if err := option.validateValue(fieldTag, option.DefaultValue); err != nil {
+				return errors.New(fmt.Sprintf("Tag %s has incorrect default value of the option %s: %s", t.Name, option.Name, err))
+			}
+		}
+	}
+
+	return nil
+}
+
 // New initialize of a tag
 //  New("json")
 func New(name string) *Tag {
@@ -0,0 +1,214 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tag grammar
+//
+//   part  := key | key KeyValue value
+//   tag   := part (KeysSeparator part)*
+//
+// Key or value may be quoted with single or double quotes (`regexp:'^a|b$'`).
+// Quote is special only when it opens the key or the value, so `default:it's` is the value "it's".
+// Backslash escapes the symbols and quotes (`regexp:^a\|b$`), other backslashes are kept as is: `pattern:^\d+$`.
+// Only the first KeyValue symbol splits the key from the value,
+// so `default:a:b` is the key "default" with the value "a:b".
+// Value of a key declared as a list (Opt("alias").Multiple()) in square brackets is a list,
+// every item of the list is a value of the key:
+// `alias:[a, 'b,c']` is the key "alias" with the values "a" and "b,c" (`[]` defines nothing).
+// Values of other keys are kept as is: `pattern:[a-z]`.
+
+// scanTag walks through the raw tag and calls found for every separator and the first KeyValue symbol of every part
+// which aren't quoted or escaped. Scanning stops if found returns false.
+// Returns the quote which isn't terminated or 0.
+func scanTag(raw string, symbols TagSymbols, found func(index int, symbol string) bool) (quote byte) {
+	// start of a key or a value, only there a quote is special
+	start := true
+	hasKey := false
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if quote != 0 {
+			if c == '\\' && i+1 < len(raw) && raw[i+1] == quote {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch {
+		case c == '\\' && isEscaped(raw[i+1:], symbols):
+			i++
+			start = false
+		case start && (c == '\'' || c == '"'):
+			quote = c
+			start = false
+		case len(symbols.KeysSeparator) != 0 && strings.HasPrefix(raw[i:], symbols.KeysSeparator):
+			if found != nil && !found(i, symbols.KeysSeparator) {
+				return
+			}
+			i += len(symbols.KeysSeparator) - 1
+			start, hasKey = true, false
+		case !hasKey && len(symbols.KeyValue) != 0 && strings.HasPrefix(raw[i:], symbols.KeyValue):
+			if found != nil && !found(i, symbols.KeyValue) {
+				return
+			}
+			i += len(symbols.KeyValue) - 1
+			start, hasKey = true, true
+		default:
+			start = start && c == ' '
+		}
+	}
+
+	return
+}
+
+// isEscaped returns true if backslash before the rest escapes it: quotes and the symbols are escaped only
+func isEscaped(rest string, symbols TagSymbols) bool {
+	if len(rest) == 0 {
+		return false
+	}
+
+	return rest[0] == '\'' || rest[0] == '"' ||
+		(len(symbols.KeysSeparator) != 0 && strings.HasPrefix(rest, symbols.KeysSeparator)) ||
+		(len(symbols.KeyValue) != 0 && strings.HasPrefix(rest, symbols.KeyValue))
+}
+
+// splitTag splits raw tag by the separator which isn't quoted or escaped.
+// Parts are returned as is (with quotes and escapes).
+func splitTag(raw string, symbols TagSymbols) []string {
+	if len(symbols.KeysSeparator) == 0 {
+		return []string{raw}
+	}
+
+	var parts []string
+	start := 0
+	scanTag(raw, symbols, func(index int, symbol string) bool {
+		if symbol == symbols.KeysSeparator {
+			parts = append(parts, raw[start:index])
+			start = index + len(symbol)
+		}
+
+		return true
+	})
+
+	return append(parts, raw[start:])
+}
+
+// unquoteTag removes quotes and escapes from the raw key or value of a tag
+//
+//	'a|b' -> a|b
+//	a\:b  -> a:b
+//	it's  -> it's
+func unquoteTag(raw string, symbols TagSymbols) string {
+	if !strings.ContainsAny(raw, `\'"`) {
+		return raw
+	}
+
+	var (
+		builder strings.Builder
+		quote   byte
+	)
+	builder.Grow(len(raw))
+	start := true
+	for i := 0; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case quote != 0 && c == '\\' && i+1 < len(raw) && raw[i+1] == quote:
+			i++
+			builder.WriteByte(raw[i])
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			builder.WriteByte(c)
+		case c == '\\' && isEscaped(raw[i+1:], symbols):
+			i++
+			builder.WriteByte(raw[i])
+			start = false
+		case start && (c == '\'' || c == '"'):
+			quote = c
+			start = false
+		default:
+			builder.WriteByte(c)
+			start = start && c == ' '
+		}
+	}
+
+	return builder.String()
+}
+
+// splitKeyValue splits raw part of a tag to the key and value.
+// Value is empty and ok is false if part doesn't contain KeyValue symbol.
+func splitKeyValue(raw string, symbols TagSymbols) (key, value string, ok bool) {
+	index := -1
+	scanTag(raw, symbols, func(i int, symbol string) bool {
+		if symbol == symbols.KeyValue {
+			index = i
+		}
+
+		return index == -1
+	})
+	if index == -1 {
+		return raw, "", false
+	}
+
+	return raw[:index], raw[index+len(symbols.KeyValue):], true
+}
+
+// listSymbols symbols of items of a list
+var listSymbols = TagSymbols{KeysSeparator: ","}
+
+// splitList splits raw value of a tag to the items if it's a list: [a, b]
+func splitList(raw string) ([]string, bool) {
+	if len(raw) < 2 || raw[0] != '[' || raw[len(raw)-1] != ']' {
+		return nil, false
+	}
+
+	inner := strings.TrimSpace(raw[1 : len(raw)-1])
+	if len(inner) == 0 {
+		return nil, true
+	}
+
+	items := splitTag(inner, listSymbols)
+	for i, item := range items {
+		items[i] = unquoteTag(strings.TrimSpace(item), listSymbols)
+	}
+
+	return items, true
+}
+
+// checkTag returns an error if the raw tag is malformed:
+// unterminated quote or list, repeated separator or empty key
+func checkTag(raw string, symbols TagSymbols, isListKey func(key string) bool) error {
+	if quote := scanTag(raw, symbols, nil); quote != 0 {
+		return errors.New(fmt.Sprintf("Unterminated quote %c", quote))
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+
+	for _, part := range splitTag(raw, symbols) {
+		if len(part) == 0 {
+			return errors.New(fmt.Sprintf("Empty part of the tag (repeated separator %q)", symbols.KeysSeparator))
+		}
+
+		key, value, ok := splitKeyValue(part, symbols)
+		if !ok {
+			continue
+		}
+
+		if len(key) == 0 {
+			return errors.New(fmt.Sprintf("Empty key in %q", part))
+		}
+
+		if isListKey(unquoteTag(key, symbols)) && strings.HasPrefix(value, "[") && !strings.HasSuffix(value, "]") {
+			return errors.New(fmt.Sprintf("Unterminated list in %q", part))
+		}
+	}
+
+	return nil
+}
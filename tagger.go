@@ -1,8 +1,11 @@
 package tagger
 
+import "reflect"
+
 type Tagger interface {
-	// Add your tag
-	//    tagger.Add(tagger.New("test").InContract(nil))
+	// Add your tag. Must-style (as regexp.MustCompile): panics when the tag can't be registered,
+	// use Registrar.Register for to get an error
+	//    tagger.Add(tagger.New("test").InContract(myContract{}))
 	//    tagger.Add(tagger.New("test").InFunction(func() {}))
 	Add(tag *Tag) Tagger
 	// In for fill the struct
@@ -45,3 +48,44 @@ type Tagger interface {
 	//    tagger.Out(&loggingData, &User{Username: "username", ID: 1}, "foo") // ID will processed by 'foo' tag
 	Out(data any, out any, tagForEmpty string, tags ...string) (any, error)
 }
+
+// Registrar manages registered tags of a tagger
+type Registrar interface {
+	// Register your tag. Returns an error when the tag can't be registered: definition of the tag is incorrect
+	// (no handlers, both function and contract for the same operation, incorrect options),
+	// a tag with the same name is already registered or ErrFrozen after Freeze.
+	// Safe for concurrent use with In/Out: calls which already started use the previously registered tags.
+	Register(tag *Tag) error
+	// Replace the same as Register, but overrides a registered tag with the same name
+	Replace(tag *Tag) error
+	// Include registers tags of the named registries: tag "json" of the registry "billing" is registered as "billing.json".
+	// Nothing is registered when any of the tags can't be registered.
+	//
+	//    tagger.Include(NewRegistry("billing", New("json").InFunction(func() {})))
+	//    type Invoice struct {
+	//      ID uint `billing.json:"id"`
+	//    }
+	Include(registries ...*Registry) error
+	// Merge registers tags of the other tagger in order of their registration. Tags with the same names are overridden.
+	// Nothing is registered when any of the tags can't be registered.
+	Merge(other Registrar) error
+	// Tags returns registered tags
+	Tags() Tags
+	// Names returns names of the registered tags in order of registration (handlers of a field are called in this order)
+	Names() []string
+	// Freeze forbids registration of new tags (Add panics and Register returns ErrFrozen)
+	Freeze()
+	// IsFrozen was Freeze called
+	IsFrozen() bool
+}
+
+// Explainer explains how a tagger sees structs
+type Explainer interface {
+	// Explain returns how the tagger sees a struct: fields, matched tags, parsed keys and handlers for In/Out.
+	// Options are the same as arguments of In/Out.
+	//
+	//    explanation, _ := tagger.Explain(reflect.TypeOf(User{}), ExplainOptions{TagForEmpty: "foo"})
+	//    fmt.Println(explanation)
+	//    json.Marshal(explanation)
+	Explain(typeOf reflect.Type, options ExplainOptions) (Explanation, error)
+}
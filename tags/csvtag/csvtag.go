@@ -0,0 +1,333 @@
+// Package csvtag provides the "csv" tag which fills structs from CSV records by In and exports them by Out:
+// `csv:"name"` is the column of the header, `csv:",index=2"` is the column by the index (from 0),
+// `csv:"price,format=%.2f"` and `csv:"date,format=2006-01-02"` are formats of numbers (only for Out) and times.
+// Formats with commas must be quoted: `csv:"date,format='Jan 2, 2006'"`.
+//
+// Fields without tags are columns by their names (or by the naming strategy), fields of nested structs are columns too.
+//
+//	t := tagger.NewReflectionTagger().Add(csvtag.New())
+//
+//	reader := csvtag.NewReader(t, csv.NewReader(file))
+//	for {
+//	  var user User
+//	  if err := reader.Read(&user); err == io.EOF {
+//	    break
+//	  }
+//	}
+//
+//	writer := csvtag.NewWriter(t, csv.NewWriter(os.Stdout))
+//	err := writer.WriteAll(users)
+package csvtag
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/internal/convert"
+	"github.com/shindakioku/tagger/internal/fields"
+)
+
+// Name of the tag
+const Name = "csv"
+
+// textUnmarshalerType structs which are read from a cell as a whole (as by convert)
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// New returns the "csv" tag
+func New() *tagger.Tag {
+	return NewTag(Name)
+}
+
+// NewTag returns the tag under another name: NewTag("report") for `report:"total"`
+func NewTag(name string) *tagger.Tag {
+	c := &codec{name: name}
+
+	return tagger.New(name).Symbols("=", ",").InFunction(c.in).OutFunction(c.out)
+}
+
+// Record values of the record with the header.
+// In reads values by the columns of the header. Out adds the columns, Marshal lays them out to the header and the values
+// by the type of the struct.
+type Record struct {
+	Header []string
+	Values []string
+
+	indexes map[string]int
+	// columns added by Out
+	columns []column
+	// indexes of the columns with the index option: [index] -> name
+	taken map[int]string
+	// positions of the columns in order of adding (the same for every record of a type), nil until the layout
+	positions []int
+	// next position of the layout for the added column
+	next int
+}
+
+// column of Out
+type column struct {
+	name  string
+	index int
+	value string
+}
+
+// NewRecord initialize of a record for In
+func NewRecord(header, values []string) *Record {
+	return &Record{
+		Header: header,
+		Values: values,
+	}
+}
+
+// Value returns value of the column
+func (r *Record) Value(column string) (string, bool) {
+	if r.indexes == nil {
+		r.indexes = make(map[string]int, len(r.Header))
+		for i, name := range r.Header {
+			if _, exists := r.indexes[name]; !exists {
+				r.indexes[name] = i
+			}
+		}
+	}
+
+	index, exists := r.indexes[column]
+	if !exists {
+		return "", false
+	}
+
+	return r.Index(index)
+}
+
+// Index returns value of the column by the index
+func (r *Record) Index(index int) (string, bool) {
+	if index < 0 || index >= len(r.Values) {
+		return "", false
+	}
+
+	return r.Values[index], true
+}
+
+// set adds the column. The value is assigned by its position if the layout of the header is already known:
+// columns which aren't added (fields of nil nested structs) stay empty.
+func (r *Record) set(name string, index int, value string) error {
+	if r.positions != nil {
+		for ; r.next < len(r.positions); r.next++ {
+			if position := r.positions[r.next]; r.Header[position] == name {
+				r.Values[position] = value
+				r.next++
+
+				return nil
+			}
+		}
+
+		return errors.New(fmt.Sprintf("column %s doesn't match the header", name))
+	}
+
+	if index >= 0 {
+		if existing, exists := r.taken[index]; exists {
+			return errors.New(fmt.Sprintf("index %d is already used by the column %s", index, existing))
+		}
+
+		if r.taken == nil {
+			r.taken = make(map[int]string)
+		}
+		r.taken[index] = name
+	}
+	r.columns = append(r.columns, column{name: name, index: index, value: value})
+
+	return nil
+}
+
+// layOut lays out the added columns to the header and the values only once:
+// columns with indexes take their positions, other columns take free positions in the order of adding
+func (r *Record) layOut() {
+	if r.positions != nil {
+		return
+	}
+
+	size := len(r.columns)
+	for index := range r.taken {
+		if index >= size {
+			size = index + 1
+		}
+	}
+
+	r.Header, r.Values = make([]string, size), make([]string, size)
+	r.positions = make([]int, len(r.columns))
+	position := 0
+	last := -1
+	for i, c := range r.columns {
+		if c.index < 0 {
+			for ; ; position++ {
+				if _, exists := r.taken[position]; !exists {
+					break
+				}
+			}
+			r.positions[i] = position
+			position++
+		} else {
+			r.positions[i] = c.index
+		}
+
+		r.Header[r.positions[i]] = c.name
+		r.Values[r.positions[i]] = c.value
+		if r.positions[i] > last {
+			last = r.positions[i]
+		}
+	}
+	r.Header, r.Values = r.Header[:last+1], r.Values[:last+1]
+}
+
+// withLayout returns an empty record with the same layout of the header
+func (r *Record) withLayout() *Record {
+	return &Record{Header: r.Header, Values: make([]string, len(r.Values)), positions: r.positions}
+}
+
+// Unmarshal fills the struct from the record by the header
+func Unmarshal(t tagger.Tagger, header, record []string, v any, tags ...string) error {
+	return t.In(NewRecord(header, record), v, Name, tags...)
+}
+
+// Marshal exports the struct to the header and the record.
+// The header has columns of all the fields of the type: columns of nil nested structs are empty.
+func Marshal(t tagger.Tagger, v any, tags ...string) (header []string, record []string, err error) {
+	layout, err := layoutOf(t, v, tags...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output, err := marshal(t, layout.withLayout(), v, tags...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return layout.Header, output.Values, nil
+}
+
+// layoutOf lays out the columns of the type of the struct by its zero value with allocated nested structs
+func layoutOf(t tagger.Tagger, v any, tags ...string) (*Record, error) {
+	typeOf := reflect.TypeOf(v)
+	for typeOf != nil && typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	// The tagger reports what's wrong with the value
+	if typeOf == nil || typeOf.Kind() != reflect.Struct {
+		return marshal(t, &Record{}, v, tags...)
+	}
+
+	zero := reflect.New(typeOf)
+	fields.Allocate(zero)
+
+	return marshal(t, &Record{}, zero.Interface(), tags...)
+}
+
+// marshal exports the struct to the record and lays it out
+func marshal(t tagger.Tagger, record *Record, v any, tags ...string) (*Record, error) {
+	if _, err := t.Out(record, v, Name, tags...); err != nil {
+		return nil, err
+	}
+	record.layOut()
+
+	return record, nil
+}
+
+type codec struct {
+	name string
+}
+
+// options of the field from the tag
+type options struct {
+	name   string
+	skip   bool
+	index  int
+	format string
+}
+
+func (c *codec) options(field *tagger.Field) (options, error) {
+	parts := field.Tag.Parts()
+	if len(parts) != 0 && parts[0] == "-" {
+		return options{skip: true}, nil
+	}
+
+	output := options{name: field.Key(c.name), index: -1}
+	if len(parts) < 2 {
+		return output, nil
+	}
+
+	rest := fields.Rest(field, parts)
+
+	output.format, _ = rest.FindByKey("format")
+	if index, exists := rest.FindByKey("index"); exists {
+		parsed, err := strconv.Atoi(index)
+		if err != nil || parsed < 0 {
+			return output, errors.New(fmt.Sprintf("csvtag: incorrect index %s of the field %s", index, field.Path()))
+		}
+		output.index = parsed
+	}
+
+	return output, nil
+}
+
+func (c *codec) in(data any, field *tagger.Field, _ *reflect.Value) error {
+	record, ok := data.(*Record)
+	if !ok {
+		return errors.New(fmt.Sprintf("csvtag: data for In must be *Record, got %T", data))
+	}
+
+	fieldOptions, err := c.options(field)
+	if err != nil || fieldOptions.skip || !fields.IsColumn(field, textUnmarshalerType) {
+		return err
+	}
+
+	var (
+		value  string
+		exists bool
+	)
+	if fieldOptions.index >= 0 {
+		value, exists = record.Index(fieldOptions.index)
+	} else {
+		value, exists = record.Value(fieldOptions.name)
+	}
+
+	// Empty cells leave zero values (nil pointers)
+	if !exists || len(value) == 0 {
+		if field.StructField.Type.Kind() == reflect.Ptr {
+			field.Value.Set(reflect.Zero(field.Value.Type()))
+		}
+
+		return nil
+	}
+
+	if err = convert.SetString(field.Value, value, fieldOptions.format); err != nil {
+		return errors.New(fmt.Sprintf("csvtag: can't read the column %s to the field %s: %s", fieldOptions.name, field.Path(), err))
+	}
+
+	return nil
+}
+
+func (c *codec) out(data any, field *tagger.Field, _ *reflect.Value) (any, error) {
+	record, ok := data.(*Record)
+	if !ok {
+		return data, errors.New(fmt.Sprintf("csvtag: data for Out must be *Record, got %T", data))
+	}
+
+	fieldOptions, err := c.options(field)
+	if err != nil || fieldOptions.skip || !fields.IsColumn(field, textUnmarshalerType) {
+		return data, err
+	}
+
+	value, err := convert.String(field.Value, fieldOptions.format)
+	if err != nil {
+		return data, errors.New(fmt.Sprintf("csvtag: can't write the field %s: %s", field.Path(), err))
+	}
+
+	if err = record.set(fieldOptions.name, fieldOptions.index, value); err != nil {
+		return data, errors.New(fmt.Sprintf("csvtag: can't write the field %s: %s", field.Path(), err))
+	}
+
+	return data, nil
+}
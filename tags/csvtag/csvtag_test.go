@@ -0,0 +1,173 @@
+package csvtag
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shindakioku/tagger"
+	"github.com/stretchr/testify/assert"
+)
+
+type Money struct {
+	Amount   float64 `csv:"amount,format=%.2f"`
+	Currency string  `csv:"currency"`
+}
+
+type Order struct {
+	ID        int64      `csv:"id"`
+	Customer  string     `csv:"customer"`
+	Date      time.Time  `csv:"date,format='Jan 2, 2006'"`
+	Shipped   *time.Time `csv:"shipped,format=2006-01-02"`
+	Quantity  *int       `csv:"quantity"`
+	Note      string     `csv:"-"`
+	Status    string
+	Price     Money
+	Reference string `csv:",index=7"`
+}
+
+func newTagger() tagger.Tagger {
+	return tagger.NewReflectionTagger().Add(New())
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	header := []string{"id", "customer", "date", "shipped", "quantity", "Note", "Status", "ref", "amount", "currency"}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Columns by the header and the index",
+			expect: func() bool {
+				var order Order
+				err := Unmarshal(newTagger(), header, []string{
+					"1", "foo", "Feb 3, 2024", "2024-02-05", "", "note", "new", "R-1", "10.5", "EUR",
+				}, &order)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, int64(1), order.ID) &&
+					assert.Equal(t, "foo", order.Customer) &&
+					assert.Equal(t, time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), order.Date) &&
+					assert.Equal(t, time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), *order.Shipped) &&
+					assert.Nil(t, order.Quantity) &&
+					assert.Empty(t, order.Note) &&
+					assert.Equal(t, "new", order.Status) &&
+					assert.Equal(t, "R-1", order.Reference) &&
+					assert.Equal(t, Money{Amount: 10.5, Currency: "EUR"}, order.Price)
+			},
+		},
+		{
+			name: "Missing columns",
+			expect: func() bool {
+				var order Order
+				err := Unmarshal(newTagger(), []string{"id"}, []string{"2"}, &order)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, int64(2), order.ID) &&
+					assert.Nil(t, order.Shipped) &&
+					assert.Empty(t, order.Reference)
+			},
+		},
+		{
+			name: "Incorrect value",
+			expect: func() bool {
+				var order Order
+				err := Unmarshal(newTagger(), []string{"id"}, []string{"first"}, &order)
+
+				return assert.ErrorContains(t, err, "csvtag: can't read the column id to the field ID")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestUnmarshal] %s is not true", c.name))
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	quantity := 3
+	order := Order{
+		ID:        1,
+		Customer:  "foo",
+		Date:      time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+		Quantity:  &quantity,
+		Note:      "note",
+		Status:    "new",
+		Price:     Money{Amount: 10.5, Currency: "EUR"},
+		Reference: "R-1",
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Header and record",
+			expect: func() bool {
+				header, record, err := Marshal(newTagger(), &order)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, []string{"id", "customer", "date", "shipped", "quantity", "Status", "amount", "Reference", "currency"}, header) &&
+					assert.Equal(t, []string{"1", "foo", "Feb 3, 2024", "", "3", "new", "10.50", "R-1", "EUR"}, record)
+			},
+		},
+		{
+			name: "Columns of nil nested structs are empty",
+			expect: func() bool {
+				header, record, err := Marshal(newTagger(), &Shipment{ID: "1", Note: "a"})
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, []string{"id", "city", "", "note"}, header) &&
+					assert.Equal(t, []string{"1", "", "", "a"}, record)
+			},
+		},
+		{
+			name: "Columns with indexes take their positions",
+			expect: func() bool {
+				header, record, err := Marshal(newTagger(), &struct {
+					A string `csv:"a"`
+					B string `csv:"b,index=0"`
+					C string `csv:"c,index=3"`
+				}{A: "1", B: "2", C: "3"})
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, []string{"b", "a", "", "c"}, header) &&
+					assert.Equal(t, []string{"2", "1", "", "3"}, record)
+			},
+		},
+		{
+			name: "Index is already used",
+			expect: func() bool {
+				_, _, err := Marshal(newTagger(), &struct {
+					A string `csv:"a,index=0"`
+					B string `csv:"b,index=0"`
+				}{})
+
+				return assert.EqualError(t, err, "csvtag: can't write the field B: index 0 is already used by the column a")
+			},
+		},
+		{
+			name: "Incorrect index",
+			expect: func() bool {
+				_, _, err := Marshal(newTagger(), &struct {
+					A string `csv:"a,index=first"`
+				}{})
+
+				return assert.EqualError(t, err, "csvtag: incorrect index first of the field A")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestMarshal] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package csvtag
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/shindakioku/tagger"
+)
+
+// byteOrderMark is removed from the first column of the header (files saved by Excel)
+const byteOrderMark = "\uFEFF"
+
+// Reader reads structs from records of the csv.Reader. The first record is the header.
+type Reader struct {
+	t      tagger.Tagger
+	reader *csv.Reader
+	tags   []string
+	header []string
+}
+
+// NewReader initialize of a reader
+func NewReader(t tagger.Tagger, reader *csv.Reader, tags ...string) *Reader {
+	return &Reader{
+		t:      t,
+		reader: reader,
+		tags:   tags,
+	}
+}
+
+// Header returns the header (reads it if it's not read yet)
+func (r *Reader) Header() ([]string, error) {
+	if r.header != nil {
+		return r.header, nil
+	}
+
+	header, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(header) != 0 {
+		header[0] = strings.TrimPrefix(header[0], byteOrderMark)
+	}
+	// csv.Reader can reuse the slice of the record
+	r.header = append([]string(nil), header...)
+
+	return r.header, nil
+}
+
+// Read fills the struct from the next record. Returns io.EOF if there are no more records.
+func (r *Reader) Read(v any) error {
+	header, err := r.Header()
+	if err != nil {
+		return err
+	}
+
+	record, err := r.reader.Read()
+	if err != nil {
+		return err
+	}
+
+	return Unmarshal(r.t, header, record, v, r.tags...)
+}
+
+// ReadAll fills the slice of structs (or pointers to structs) from all the remaining records
+//
+//	var users []User
+//	err := reader.ReadAll(&users)
+func (r *Reader) ReadAll(slice any) error {
+	sliceOf := reflect.ValueOf(slice)
+	if sliceOf.Kind() != reflect.Ptr || sliceOf.Elem().Kind() != reflect.Slice {
+		return errors.New(fmt.Sprintf("csvtag: expected pointer to a slice, got %T", slice))
+	}
+	sliceOf = sliceOf.Elem()
+
+	elemType := sliceOf.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	for {
+		elem := reflect.New(elemType)
+		err := r.Read(elem.Interface())
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		sliceOf.Set(reflect.Append(sliceOf, elem))
+	}
+}
+
+// Writer writes structs as records to the csv.Writer. The header is written before the first record.
+// Columns are laid out only once by the type of the first struct, values of other records are assigned by their positions.
+type Writer struct {
+	t      tagger.Tagger
+	writer *csv.Writer
+	tags   []string
+	// the layout of the header
+	layout *Record
+}
+
+// NewWriter initialize of a writer
+func NewWriter(t tagger.Tagger, writer *csv.Writer, tags ...string) *Writer {
+	return &Writer{
+		t:      t,
+		writer: writer,
+		tags:   tags,
+	}
+}
+
+// Write writes the struct (and the header before the first struct). Call Flush after the last struct.
+func (w *Writer) Write(v any) error {
+	if w.layout == nil {
+		layout, err := layoutOf(w.t, v, w.tags...)
+		if err != nil {
+			return err
+		}
+
+		if err = w.writer.Write(layout.Header); err != nil {
+			return err
+		}
+		w.layout = layout
+	}
+
+	record, err := marshal(w.t, w.layout.withLayout(), v, w.tags...)
+	if err != nil {
+		return err
+	}
+
+	return w.writer.Write(record.Values)
+}
+
+// WriteAll writes the slice of structs (or pointers to structs) and flushes the writer
+func (w *Writer) WriteAll(slice any) error {
+	sliceOf := reflect.ValueOf(slice)
+	if sliceOf.Kind() != reflect.Slice {
+		return errors.New(fmt.Sprintf("csvtag: expected a slice, got %T", slice))
+	}
+
+	for i := 0; i < sliceOf.Len(); i++ {
+		elem := sliceOf.Index(i)
+		if elem.Kind() != reflect.Ptr {
+			elem = elem.Addr()
+		}
+
+		if err := w.Write(elem.Interface()); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// Flush writes buffered records and returns an error of the writing
+func (w *Writer) Flush() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
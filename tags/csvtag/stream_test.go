@@ -0,0 +1,130 @@
+package csvtag
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Product struct {
+	SKU   string  `csv:"sku"`
+	Name  string  `csv:"name"`
+	Price float64 `csv:"price,format=%.2f"`
+	Stock *int    `csv:"stock"`
+}
+
+type ShipmentAddress struct {
+	City string `csv:"city"`
+}
+
+type Shipment struct {
+	ID      string `csv:"id"`
+	Address *ShipmentAddress
+	Note    string `csv:"note,index=3"`
+}
+
+const products = "\uFEFFsku,name,price,stock\n" +
+	"A-1,\"Chair, black\",10.5,3\n" +
+	"A-2,Table,99,\n"
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Read",
+			expect: func() bool {
+				reader := NewReader(newTagger(), csv.NewReader(strings.NewReader(products)))
+
+				var first, second, third Product
+				header, err := reader.Header()
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, []string{"sku", "name", "price", "stock"}, header) &&
+					assert.NoError(t, reader.Read(&first)) &&
+					assert.NoError(t, reader.Read(&second)) &&
+					assert.True(t, errors.Is(reader.Read(&third), io.EOF)) &&
+					assert.Equal(t, "Chair, black", first.Name) &&
+					assert.Equal(t, 3, *first.Stock) &&
+					assert.Equal(t, 99.0, second.Price) &&
+					assert.Nil(t, second.Stock)
+			},
+		},
+		{
+			name: "ReadAll and WriteAll",
+			expect: func() bool {
+				var read []*Product
+				if !assert.NoError(t, NewReader(newTagger(), csv.NewReader(strings.NewReader(products))).ReadAll(&read)) {
+					return false
+				}
+
+				output := new(bytes.Buffer)
+				err := NewWriter(newTagger(), csv.NewWriter(output)).WriteAll(read)
+
+				return assert.NoError(t, err) &&
+					assert.Len(t, read, 2) &&
+					assert.Equal(t, "sku,name,price,stock\nA-1,\"Chair, black\",10.50,3\nA-2,Table,99.00,\n", output.String())
+			},
+		},
+		{
+			name: "Write",
+			expect: func() bool {
+				output := new(bytes.Buffer)
+				writer := NewWriter(newTagger(), csv.NewWriter(output))
+
+				return assert.NoError(t, writer.Write(Product{SKU: "A-3", Name: "Lamp", Price: 5})) &&
+					assert.NoError(t, writer.Write(&Product{SKU: "A-4", Name: "Desk", Price: 7.25})) &&
+					assert.NoError(t, writer.Flush()) &&
+					assert.Equal(t, "sku,name,price,stock\nA-3,Lamp,5.00,\nA-4,Desk,7.25,\n", output.String())
+			},
+		},
+		{
+			name: "Write by the layout of the type",
+			expect: func() bool {
+				output := new(bytes.Buffer)
+				writer := NewWriter(newTagger(), csv.NewWriter(output))
+
+				return assert.NoError(t, writer.Write(Shipment{ID: "1", Address: &ShipmentAddress{City: "Kyiv"}, Note: "a"})) &&
+					assert.NoError(t, writer.Write(Shipment{ID: "2", Note: "b"})) &&
+					assert.NoError(t, writer.Write(Shipment{ID: "3", Address: &ShipmentAddress{City: "Lviv"}})) &&
+					assert.NoError(t, writer.Flush()) &&
+					assert.Equal(t, "id,city,,note\n1,Kyiv,,a\n2,,,b\n3,Lviv,,\n", output.String())
+			},
+		},
+		{
+			name: "Write the first record with a nil nested struct",
+			expect: func() bool {
+				output := new(bytes.Buffer)
+				writer := NewWriter(newTagger(), csv.NewWriter(output))
+
+				return assert.NoError(t, writer.Write(Shipment{ID: "1"})) &&
+					assert.NoError(t, writer.Write(Shipment{ID: "2", Address: &ShipmentAddress{City: "Kyiv"}})) &&
+					assert.NoError(t, writer.Flush()) &&
+					assert.Equal(t, "id,city,,note\n1,,,\n2,Kyiv,,\n", output.String())
+			},
+		},
+		{
+			name: "ReadAll expects a slice",
+			expect: func() bool {
+				err := NewReader(newTagger(), csv.NewReader(strings.NewReader(products))).ReadAll(&Product{})
+
+				return assert.EqualError(t, err, "csvtag: expected pointer to a slice, got *csvtag.Product")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestStream] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Package flagtag provides the "flag" tag which defines flags of struct fields on a flag.FlagSet by Out
+// and fills the fields from the parsed flags by In, so configuration of a command is declared once:
+//
+//	type Config struct {
+//	  Port    int           `flag:"port,short=p,usage='Port to listen'" default:"8080"`
+//	  Verbose bool          `flag:"verbose,short=v"`
+//	  Tags    []string      `flag:"tag,usage=Tag of the instance (repeated)"`
+//	  Timeout time.Duration `flag:",usage=Request timeout" default:"5s"`
+//	  DB      Database
+//	}
+//
+//	type Database struct {
+//	  Host string `flag:"host"` // -db-host
+//	}
+//
+//	t := tagger.NewReflectionTagger().Add(flagtag.New())
+//	var config Config
+//	err := flagtag.Parse(t, flag.CommandLine, os.Args[1:], &config)
+//
+// Names of the flags are kebab-case names of the fields by default, fields of nested structs are prefixed
+// by names of the parent fields (embedded structs don't add prefixes). Nested pointers must be allocated for Define.
+// Defaults are values of the "default" tag or values of the fields at Define. Slice flags collect repeated flags.
+package flagtag
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/internal/convert"
+	"github.com/shindakioku/tagger/internal/fields"
+)
+
+// Name of the tag
+const Name = "flag"
+
+// DefaultTag tag of default values: `default:"8080"` (comma-separated for slices)
+const DefaultTag = "default"
+
+// PrefixSeparator separates names of parent fields and the flag: -db-host
+const PrefixSeparator = "-"
+
+// New returns the "flag" tag
+func New() *tagger.Tag {
+	return NewTag(Name)
+}
+
+// NewTag returns the tag under another name: NewTag("cli") for `cli:"port"`
+func NewTag(name string) *tagger.Tag {
+	c := &codec{tag: tagger.New(name).Symbols("=", ",").Naming(tagger.KebabCase)}
+
+	return c.tag.InFunction(c.in).OutFunction(c.out)
+}
+
+// Define defines flags of the struct fields on the flag set.
+// Nil pointers to nested structs are allocated (as In does it), so flags of their fields are defined too.
+func Define(t tagger.Tagger, flagSet *flag.FlagSet, v any, tags ...string) error {
+	fields.Allocate(reflect.ValueOf(v))
+	_, err := t.Out(flagSet, v, "", tags...)
+
+	return err
+}
+
+// Bind fills the struct fields from the parsed flag set
+func Bind(t tagger.Tagger, flagSet *flag.FlagSet, v any, tags ...string) error {
+	return t.In(flagSet, v, "", tags...)
+}
+
+// Parse defines flags of the struct, parses the arguments and fills the struct
+func Parse(t tagger.Tagger, flagSet *flag.FlagSet, arguments []string, v any, tags ...string) error {
+	if err := Define(t, flagSet, v, tags...); err != nil {
+		return err
+	}
+
+	if err := flagSet.Parse(arguments); err != nil {
+		return err
+	}
+
+	return Bind(t, flagSet, v, tags...)
+}
+
+// value of a flag defined by the tag. Keeps raw values until In.
+type value struct {
+	// element type for the validation of values
+	typeOf   reflect.Type
+	isSlice  bool
+	values   []string
+	defaults []string
+	set      bool
+}
+
+func (v *value) String() string {
+	if v == nil {
+		return ""
+	}
+
+	if v.set {
+		return strings.Join(v.values, ",")
+	}
+
+	return strings.Join(v.defaults, ",")
+}
+
+func (v *value) Set(raw string) error {
+	if v.typeOf != nil {
+		if err := convert.SetString(reflect.New(v.typeOf).Elem(), raw, ""); err != nil {
+			return err
+		}
+	}
+
+	if !v.set || !v.isSlice {
+		v.values = nil
+	}
+	v.values = append(v.values, raw)
+	v.set = true
+
+	return nil
+}
+
+// IsBoolFlag -verbose instead of -verbose=true
+func (v *value) IsBoolFlag() bool {
+	if v == nil || v.typeOf == nil {
+		return false
+	}
+
+	return v.typeOf.Kind() == reflect.Bool && !v.isSlice
+}
+
+type codec struct {
+	tag *tagger.Tag
+}
+
+// options of the field from the tag
+type options struct {
+	name  string
+	skip  bool
+	short string
+	usage string
+}
+
+func (c *codec) options(field *tagger.Field) options {
+	parts := field.Tag.Parts()
+	if len(parts) != 0 && parts[0] == "-" {
+		return options{skip: true}
+	}
+
+	output := options{name: c.prefix(field) + field.Key(c.tag.Name)}
+	if len(parts) < 2 {
+		return output
+	}
+
+	rest := fields.Rest(field, parts)
+	output.short, _ = rest.FindByKey("short")
+	output.usage, _ = rest.FindByKey("usage")
+
+	return output
+}
+
+// prefix returns names of the parent fields: -db-host for the field Host of the field DB
+func (c *codec) prefix(field *tagger.Field) (prefix string) {
+	for parent := field.ParentStruct; parent != nil && parent.ParentField != nil; parent = parent.ParentField.ParentStruct {
+		parentField := parent.ParentField
+		if parentField.StructField.Anonymous {
+			continue
+		}
+
+		var name string
+		if _, tagged := c.tag.Lookup(parentField.StructField.Tag); tagged {
+			name = parentField.Key(c.tag.Name)
+		} else if c.tag.NamingStrategy != nil {
+			name = c.tag.NamingStrategy(parentField.Name())
+		} else {
+			name = parentField.Name()
+		}
+
+		prefix = name + PrefixSeparator + prefix
+	}
+
+	return
+}
+
+// isFlag returns false for nested structs: their fields are flags
+func isFlag(field *tagger.Field) bool {
+	return !field.IsStruct || convert.IsScalar(field.StructField.Type)
+}
+
+// out defines the flag of the field
+func (c *codec) out(data any, field *tagger.Field, _ *reflect.Value) (any, error) {
+	flagSet, ok := data.(*flag.FlagSet)
+	if !ok {
+		return data, errors.New(fmt.Sprintf("flagtag: data for Out must be *flag.FlagSet, got %T", data))
+	}
+
+	fieldOptions := c.options(field)
+	if fieldOptions.skip || !isFlag(field) {
+		return data, nil
+	}
+
+	typeOf := field.StructField.Type
+	flagValue := &value{isSlice: convert.IsSlice(typeOf)}
+	if flagValue.isSlice {
+		typeOf = typeOf.Elem()
+	}
+	flagValue.typeOf = typeOf
+
+	if raw, exists := field.StructField.Tag.Lookup(DefaultTag); exists {
+		flagValue.defaults = []string{raw}
+		if flagValue.isSlice {
+			flagValue.defaults = strings.Split(raw, ",")
+		}
+
+		for _, d := range flagValue.defaults {
+			if err := flagValue.Set(d); err != nil {
+				return data, errors.New(fmt.Sprintf("flagtag: incorrect default %s of the field %s: %s", raw, field.Path(), err))
+			}
+		}
+		flagValue.values, flagValue.set = nil, false
+	} else if current := fields.Value(field); !current.IsZero() {
+		defaults, err := convert.Strings(current, "")
+		if err != nil {
+			return data, errors.New(fmt.Sprintf("flagtag: unsupported field %s: %s", field.Path(), err))
+		}
+		flagValue.defaults = defaults
+	}
+
+	for _, name := range []string{fieldOptions.name, fieldOptions.short} {
+		if len(name) == 0 {
+			continue
+		}
+
+		if flagSet.Lookup(name) != nil {
+			return data, errors.New(fmt.Sprintf("flagtag: flag %s of the field %s is already defined", name, field.Path()))
+		}
+	}
+
+	flagSet.Var(flagValue, fieldOptions.name, fieldOptions.usage)
+	if len(fieldOptions.short) != 0 {
+		flagSet.Var(flagValue, fieldOptions.short, "shorthand for -"+fieldOptions.name)
+	}
+
+	return data, nil
+}
+
+// in fills the field from the parsed flag
+func (c *codec) in(data any, field *tagger.Field, _ *reflect.Value) error {
+	flagSet, ok := data.(*flag.FlagSet)
+	if !ok {
+		return errors.New(fmt.Sprintf("flagtag: data for In must be *flag.FlagSet, got %T", data))
+	}
+
+	fieldOptions := c.options(field)
+	if fieldOptions.skip || !isFlag(field) {
+		return nil
+	}
+
+	defined := flagSet.Lookup(fieldOptions.name)
+	if defined == nil {
+		return errors.New(fmt.Sprintf("flagtag: flag %s of the field %s isn't defined", fieldOptions.name, field.Path()))
+	}
+
+	values := []string{defined.Value.String()}
+	if flagValue, ok := defined.Value.(*value); ok {
+		values = flagValue.values
+		if !flagValue.set {
+			values = flagValue.defaults
+		}
+	}
+
+	// In allocates pointers, a flag without a value leaves nil
+	if len(values) == 0 {
+		if field.StructField.Type.Kind() == reflect.Ptr {
+			field.Value.Set(reflect.Zero(field.Value.Type()))
+		}
+
+		return nil
+	}
+
+	if err := convert.Set(field.Value, values, ""); err != nil {
+		return errors.New(fmt.Sprintf("flagtag: can't set the flag %s to the field %s: %s", fieldOptions.name, field.Path(), err))
+	}
+
+	return nil
+}
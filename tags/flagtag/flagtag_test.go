@@ -0,0 +1,166 @@
+package flagtag
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shindakioku/tagger"
+	"github.com/stretchr/testify/assert"
+)
+
+type Database struct {
+	Host string `flag:"host,usage=Host of the database" default:"localhost"`
+	Port int    `flag:"port" default:"5432"`
+}
+
+type Logging struct {
+	Level string `flag:"log-level" default:"info"`
+}
+
+type Config struct {
+	Logging
+	Port     int           `flag:"port,short=p,usage='Port to listen, 8080 by default'" default:"8080"`
+	Verbose  bool          `flag:"verbose,short=v"`
+	Tags     []string      `flag:"tag,usage=Tag of the instance (repeated)"`
+	Weights  []float64     `flag:"weight" default:"0.5,1.5"`
+	Timeout  time.Duration `flag:",usage=Request timeout" default:"5s"`
+	Name     *string       `flag:"name"`
+	Region   string        `flag:"region"`
+	Internal string        `flag:"-"`
+	DB       Database
+	Replica  *Database `flag:"replica"`
+}
+
+func newTagger() tagger.Tagger {
+	return tagger.NewReflectionTagger().Add(New())
+}
+
+func newFlagSet() *flag.FlagSet {
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.SetOutput(new(bytes.Buffer))
+
+	return flagSet
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Flags, short flags and nested prefixes",
+			expect: func() bool {
+				config := Config{Region: "eu", Replica: &Database{}}
+				err := Parse(newTagger(), newFlagSet(), []string{
+					"-p", "9090", "-v", "-tag", "a", "-tag", "b", "-timeout", "1m",
+					"-db-host", "db", "-replica-port", "6543", "-log-level", "debug", "-name", "foo",
+				}, &config)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, 9090, config.Port) &&
+					assert.True(t, config.Verbose) &&
+					assert.Equal(t, []string{"a", "b"}, config.Tags) &&
+					assert.Equal(t, []float64{0.5, 1.5}, config.Weights) &&
+					assert.Equal(t, time.Minute, config.Timeout) &&
+					assert.Equal(t, "foo", *config.Name) &&
+					assert.Equal(t, "eu", config.Region) &&
+					assert.Equal(t, Database{Host: "db", Port: 5432}, config.DB) &&
+					assert.Equal(t, Database{Host: "localhost", Port: 6543}, *config.Replica) &&
+					assert.Equal(t, "debug", config.Level)
+			},
+		},
+		{
+			name: "Defaults",
+			expect: func() bool {
+				config := Config{Replica: &Database{}}
+				err := Parse(newTagger(), newFlagSet(), nil, &config)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, 8080, config.Port) &&
+					assert.False(t, config.Verbose) &&
+					assert.Nil(t, config.Tags) &&
+					assert.Equal(t, 5*time.Second, config.Timeout) &&
+					assert.Nil(t, config.Name) &&
+					assert.Equal(t, "info", config.Level)
+			},
+		},
+		{
+			name: "Nil nested structs",
+			expect: func() bool {
+				config := Config{}
+				err := Parse(newTagger(), newFlagSet(), []string{"-replica-host", "replica"}, &config)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, &Database{Host: "replica", Port: 5432}, config.Replica)
+			},
+		},
+		{
+			name: "Usage and defaults of the flag set",
+			expect: func() bool {
+				flagSet := newFlagSet()
+				if !assert.NoError(t, Define(newTagger(), flagSet, &Config{Region: "eu", Replica: &Database{}})) {
+					return false
+				}
+
+				port := flagSet.Lookup("port")
+				short := flagSet.Lookup("p")
+
+				return assert.Equal(t, "Port to listen, 8080 by default", port.Usage) &&
+					assert.Equal(t, "8080", port.DefValue) &&
+					assert.Equal(t, "shorthand for -port", short.Usage) &&
+					assert.Equal(t, "eu", flagSet.Lookup("region").DefValue) &&
+					assert.Equal(t, "0.5,1.5", flagSet.Lookup("weight").DefValue) &&
+					assert.NotNil(t, flagSet.Lookup("timeout")) &&
+					assert.Nil(t, flagSet.Lookup("internal"))
+			},
+		},
+		{
+			name: "Incorrect value",
+			expect: func() bool {
+				err := Parse(newTagger(), newFlagSet(), []string{"-port", "first"}, &Config{Replica: &Database{}})
+
+				return assert.ErrorContains(t, err, `invalid value "first" for flag -port`)
+			},
+		},
+		{
+			name: "Incorrect default",
+			expect: func() bool {
+				err := Define(newTagger(), newFlagSet(), &struct {
+					Port int `flag:"port" default:"first"`
+				}{})
+
+				return assert.ErrorContains(t, err, "flagtag: incorrect default first of the field Port")
+			},
+		},
+		{
+			name: "Flag is already defined",
+			expect: func() bool {
+				err := Define(newTagger(), newFlagSet(), &struct {
+					Port  int `flag:"port"`
+					Other int `flag:"port"`
+				}{})
+
+				return assert.EqualError(t, err, "flagtag: flag port of the field Other is already defined")
+			},
+		},
+		{
+			name: "Flags must be defined before In",
+			expect: func() bool {
+				err := Bind(newTagger(), newFlagSet(), &Config{})
+
+				return assert.EqualError(t, err, "flagtag: flag log-level of the field Logging.Level isn't defined")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestParse] %s is not true", c.name))
+		}
+	}
+}
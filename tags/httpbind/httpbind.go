@@ -0,0 +1,298 @@
+// Package httpbind provides tags which fill structs from *http.Request by In:
+// `query:"page"`, `header:"X-Request-ID"`, `form:"name"`, `cookie:"sid"` and `path:"id"`.
+//
+// Slices get all values of the parameter, missing parameters get values of the default option
+// (`query:"page,default=1"`, `query:"sort,default=name,default=id"`) or an error with the required option.
+// Form files are bound to *multipart.FileHeader and []*multipart.FileHeader fields.
+//
+// Out writes `header:"ETag"`, `cookie:"sid,httponly,secure"` and `status:""` fields of response structs
+// (see Write).
+//
+//	type GetUsers struct {
+//	  ID        int      `path:"id"`
+//	  Page      int      `query:"page,default=1"`
+//	  Tags      []string `query:"tag"`
+//	  RequestID string   `header:"X-Request-ID"`
+//	  Session   string   `cookie:"sid,required"`
+//	}
+//
+//	t := tagger.NewReflectionTagger()
+//	err := httpbind.New().Register(t)
+//	err = httpbind.Bind(t, r, &request)
+package httpbind
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/internal/convert"
+	"github.com/shindakioku/tagger/internal/fields"
+)
+
+// Names of the tags
+const (
+	Query  = "query"
+	Header = "header"
+	Path   = "path"
+	Form   = "form"
+	Cookie = "cookie"
+	Status = "status"
+)
+
+// DefaultMaxMemory of multipart forms (the same as in net/http)
+const DefaultMaxMemory = 32 << 20
+
+var (
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// PathParam returns value of the path parameter of the request or an empty string.
+// For routers: chi.URLParam, func(r, name) string { return mux.Vars(r)[name] }
+type PathParam func(r *http.Request, name string) string
+
+// Binder configuration of the tags
+type Binder struct {
+	pathParam PathParam
+	maxMemory int64
+}
+
+// New returns the binder which reads path parameters by http.Request.PathValue (patterns of http.ServeMux).
+// Before Go 1.22 path parameters are read only by PathParam.
+func New() *Binder {
+	return &Binder{
+		pathParam: pathValue,
+		maxMemory: DefaultMaxMemory,
+	}
+}
+
+// PathParam sets the extractor of path parameters
+func (b *Binder) PathParam(pathParam PathParam) *Binder {
+	b.pathParam = pathParam
+
+	return b
+}
+
+// MaxMemory sets maximum memory of a multipart form, the rest of files is stored on disk
+func (b *Binder) MaxMemory(maxMemory int64) *Binder {
+	b.maxMemory = maxMemory
+
+	return b
+}
+
+// Tags returns the tags: query, header, path, form, cookie and status (only for responses)
+func (b *Binder) Tags() []*tagger.Tag {
+	sources := []source{
+		{name: Query, description: "query parameter", values: queryValues},
+		{name: Header, description: "header", values: headerValues, fallbackLayout: http.TimeFormat, out: writeHeader},
+		{name: Path, description: "path parameter", values: b.pathValues},
+		{name: Form, description: "form field", values: formValues, prepare: b.parseForm},
+		{name: Cookie, description: "cookie", values: cookieValues, out: writeCookie},
+	}
+
+	tags := make([]*tagger.Tag, 0, len(sources)+1)
+	for _, s := range sources {
+		tag := tagger.New(s.name).Symbols("=", ",").InFunction(s.in)
+		if s.out != nil {
+			tag.OutFunction(s.write)
+		}
+		tags = append(tags, tag)
+	}
+
+	return append(tags, tagger.New(Status).OutFunction(writeStatus))
+}
+
+// Registry returns the tags as a registry without a namespace
+func (b *Binder) Registry() *tagger.Registry {
+	return tagger.NewRegistry("", b.Tags()...)
+}
+
+// Register registers the tags in the tagger. Nothing is registered when any of the tags can't be registered.
+func (b *Binder) Register(t tagger.Registrar) error {
+	return t.Include(b.Registry())
+}
+
+// Bind fills the struct from the request
+func Bind(t tagger.Tagger, r *http.Request, v any, tags ...string) error {
+	return t.In(r, v, "", tags...)
+}
+
+// source of values of the request
+type source struct {
+	name        string
+	description string
+	values      func(r *http.Request, name string) []string
+	// prepare reads the request before values (parses the form)
+	prepare func(r *http.Request) error
+	// layout of time values which aren't in time.RFC3339
+	fallbackLayout string
+	// out writes the field to the response (nil for sources of requests only)
+	out writer
+}
+
+// options of the field from the tag
+type options struct {
+	name     string
+	skip     bool
+	defaults []string
+	required bool
+	// rest options of the tag after the name
+	rest tagger.FieldTag
+}
+
+func optionsOf(field *tagger.Field, tagName string) options {
+	parts := field.Tag.Parts()
+	if len(parts) != 0 && parts[0] == "-" {
+		return options{skip: true}
+	}
+
+	output := options{name: field.Key(tagName)}
+	if len(parts) < 2 {
+		return output
+	}
+
+	output.rest = fields.Rest(field, parts)
+	output.defaults = output.rest.FindAllByKey("default")
+	output.required = output.rest.Exists("required")
+
+	return output
+}
+
+func (s source) in(data any, field *tagger.Field, _ *reflect.Value) error {
+	r, ok := data.(*http.Request)
+	if !ok {
+		return errors.New(fmt.Sprintf("httpbind: data for In must be *http.Request, got %T", data))
+	}
+
+	fieldOptions := optionsOf(field, s.name)
+	if fieldOptions.skip {
+		return nil
+	}
+
+	if s.prepare != nil {
+		if err := s.prepare(r); err != nil {
+			return err
+		}
+	}
+
+	if isFile(field.StructField.Type) {
+		return s.bindFiles(r, field, fieldOptions)
+	}
+
+	// Fields of nested structs are filled by their tags
+	if field.IsStruct && !convert.IsScalar(field.StructField.Type) {
+		return nil
+	}
+
+	values := s.values(r, fieldOptions.name)
+	if len(values) == 0 {
+		if fieldOptions.required {
+			return errors.New(fmt.Sprintf("httpbind: %s %s is required", s.description, fieldOptions.name))
+		}
+
+		values = fieldOptions.defaults
+	}
+
+	// In allocates pointers, a missing parameter leaves nil
+	if len(values) == 0 && field.StructField.Type.Kind() == reflect.Ptr {
+		field.Value.Set(reflect.Zero(field.Value.Type()))
+
+		return nil
+	}
+
+	err := convert.Set(field.Value, values, "")
+	// Headers are read in time.RFC3339 as other sources and in the HTTP date format written by Out
+	if err != nil && len(s.fallbackLayout) != 0 && convert.Set(field.Value, values, s.fallbackLayout) == nil {
+		err = nil
+	}
+
+	if err != nil {
+		return errors.New(fmt.Sprintf(
+			"httpbind: can't bind %s %s to the field %s: %s", s.description, fieldOptions.name, field.Path(), err,
+		))
+	}
+
+	return nil
+}
+
+func isFile(typeOf reflect.Type) bool {
+	return typeOf == fileHeaderType || typeOf == fileHeadersType
+}
+
+func (s source) bindFiles(r *http.Request, field *tagger.Field, fieldOptions options) error {
+	if s.name != Form {
+		return errors.New(fmt.Sprintf("httpbind: files can be bound only by the form tag (field %s)", field.Path()))
+	}
+
+	var files []*multipart.FileHeader
+	if r.MultipartForm != nil {
+		files = r.MultipartForm.File[fieldOptions.name]
+	}
+
+	if len(files) == 0 {
+		if fieldOptions.required {
+			return errors.New(fmt.Sprintf("httpbind: file %s is required", fieldOptions.name))
+		}
+
+		field.Value.Set(reflect.Zero(field.Value.Type()))
+
+		return nil
+	}
+
+	if field.StructField.Type == fileHeadersType {
+		field.Value.Set(reflect.ValueOf(files))
+	} else {
+		field.Value.Set(reflect.ValueOf(files[0]))
+	}
+
+	return nil
+}
+
+func queryValues(r *http.Request, name string) []string {
+	return r.URL.Query()[name]
+}
+
+func headerValues(r *http.Request, name string) []string {
+	return r.Header.Values(name)
+}
+
+func cookieValues(r *http.Request, name string) (values []string) {
+	for _, cookie := range r.Cookies() {
+		if cookie.Name == name {
+			values = append(values, cookie.Value)
+		}
+	}
+
+	return
+}
+
+func (b *Binder) pathValues(r *http.Request, name string) []string {
+	if value := b.pathParam(r, name); len(value) != 0 {
+		return []string{value}
+	}
+
+	return nil
+}
+
+// formValues returns values of the body (urlencoded or multipart form)
+func formValues(r *http.Request, name string) []string {
+	return r.PostForm[name]
+}
+
+// parseForm parses the body once, next calls return immediately
+func (b *Binder) parseForm(r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errors.New(fmt.Sprintf("httpbind: can't parse the form: %s", err))
+	}
+
+	err := r.ParseMultipartForm(b.maxMemory)
+	if err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return errors.New(fmt.Sprintf("httpbind: can't parse the multipart form: %s", err))
+	}
+
+	return nil
+}
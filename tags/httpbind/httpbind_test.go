@@ -0,0 +1,248 @@
+package httpbind
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shindakioku/tagger"
+	"github.com/stretchr/testify/assert"
+)
+
+type Pagination struct {
+	Page  int      `query:"page,default=1"`
+	Limit uint     `query:"limit,default=20"`
+	Sort  []string `query:"sort,default=name,default=id"`
+}
+
+type GetUsers struct {
+	ID         int64     `path:"id"`
+	Tags       []string  `query:"tag"`
+	Since      time.Time `query:"since"`
+	Active     *bool     `query:"active"`
+	Timeout    time.Duration
+	RequestID  string   `header:"X-Request-ID"`
+	Accept     []string `header:"Accept"`
+	Session    string   `cookie:"sid"`
+	Ignored    string   `query:"-"`
+	Pagination Pagination
+}
+
+type CreateUser struct {
+	Name     string                  `form:"name,required"`
+	Roles    []string                `form:"role"`
+	Age      int                     `form:"age,default=18"`
+	Avatar   *multipart.FileHeader   `form:"avatar"`
+	Files    []*multipart.FileHeader `form:"file"`
+	Document *multipart.FileHeader   `form:"document"`
+}
+
+func newTagger(binder *Binder) tagger.Tagger {
+	t := tagger.NewReflectionTagger()
+	if err := binder.Register(t); err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func multipartRequest() *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("name", "foo")
+	_ = writer.WriteField("role", "admin")
+	_ = writer.WriteField("role", "user")
+	for _, file := range [][2]string{{"avatar", "avatar.png"}, {"file", "a.txt"}, {"file", "b.txt"}} {
+		part, _ := writer.CreateFormFile(file[0], file[1])
+		_, _ = part.Write([]byte("content of " + file[1]))
+	}
+	_ = writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/users", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return r
+}
+
+func readFile(header *multipart.FileHeader) string {
+	file, err := header.Open()
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	content, _ := io.ReadAll(file)
+
+	return string(content)
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Query, header, path and cookie",
+			expect: func() bool {
+				r := httptest.NewRequest(
+					http.MethodGet,
+					"/users/42?tag=a&tag=b&since=2024-01-02T03:04:05Z&active=true&page=3&Ignored=x",
+					nil,
+				)
+				r.Header.Set("X-Request-ID", "request")
+				r.Header.Add("Accept", "text/html")
+				r.Header.Add("Accept", "application/json")
+				r.AddCookie(&http.Cookie{Name: "sid", Value: "session"})
+
+				binder := New().PathParam(func(r *http.Request, name string) string {
+					return map[string]string{"id": "42"}[name]
+				})
+
+				var request GetUsers
+				err := Bind(newTagger(binder), r, &request)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, int64(42), request.ID) &&
+					assert.Equal(t, []string{"a", "b"}, request.Tags) &&
+					assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), request.Since) &&
+					assert.True(t, *request.Active) &&
+					assert.Equal(t, "request", request.RequestID) &&
+					assert.Equal(t, []string{"text/html", "application/json"}, request.Accept) &&
+					assert.Equal(t, "session", request.Session) &&
+					assert.Empty(t, request.Ignored) &&
+					assert.Zero(t, request.Timeout) &&
+					assert.Equal(t, Pagination{Page: 3, Limit: 20, Sort: []string{"name", "id"}}, request.Pagination)
+			},
+		},
+		{
+			name: "Missing parameters keep pointers nil",
+			expect: func() bool {
+				var request GetUsers
+				err := Bind(newTagger(New()), httptest.NewRequest(http.MethodGet, "/users", nil), &request)
+
+				return assert.NoError(t, err) &&
+					assert.Nil(t, request.Active) &&
+					assert.Nil(t, request.Tags) &&
+					assert.Equal(t, 1, request.Pagination.Page)
+			},
+		},
+		{
+			name: "Custom path parameters",
+			expect: func() bool {
+				binder := New().PathParam(func(r *http.Request, name string) string {
+					return map[string]string{"id": "7"}[name]
+				})
+
+				var request GetUsers
+				err := Bind(newTagger(binder), httptest.NewRequest(http.MethodGet, "/users/7", nil), &request)
+
+				return assert.NoError(t, err) && assert.Equal(t, int64(7), request.ID)
+			},
+		},
+		{
+			name: "Header time in RFC 3339 and in the HTTP date format",
+			expect: func() bool {
+				modified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+				rfc := httptest.NewRequest(http.MethodGet, "/", nil)
+				rfc.Header.Set("Last-Modified", modified.Format(time.RFC3339))
+				httpDate := httptest.NewRequest(http.MethodGet, "/", nil)
+				httpDate.Header.Set("Last-Modified", modified.Format(http.TimeFormat))
+
+				var fromRFC, fromHTTPDate Cache
+
+				return assert.NoError(t, Bind(newTagger(New()), rfc, &fromRFC)) &&
+					assert.NoError(t, Bind(newTagger(New()), httpDate, &fromHTTPDate)) &&
+					assert.True(t, modified.Equal(fromRFC.Modified)) &&
+					assert.True(t, modified.Equal(fromHTTPDate.Modified))
+			},
+		},
+		{
+			name: "Incorrect header time",
+			expect: func() bool {
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				r.Header.Set("Last-Modified", "yesterday")
+
+				var cache Cache
+				err := Bind(newTagger(New()), r, &cache)
+
+				return assert.ErrorContains(t, err, "httpbind: can't bind header Last-Modified to the field Modified")
+			},
+		},
+		{
+			name: "Urlencoded form",
+			expect: func() bool {
+				form := url.Values{"name": {"foo"}, "role": {"admin", "user"}, "age": {"30"}}
+				r := httptest.NewRequest(http.MethodPost, "/users?name=query", strings.NewReader(form.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+				var request CreateUser
+				err := Bind(newTagger(New()), r, &request)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, "foo", request.Name) &&
+					assert.Equal(t, []string{"admin", "user"}, request.Roles) &&
+					assert.Equal(t, 30, request.Age) &&
+					assert.Nil(t, request.Avatar) &&
+					assert.Nil(t, request.Files)
+			},
+		},
+		{
+			name: "Multipart form with files",
+			expect: func() bool {
+				var request CreateUser
+				err := Bind(newTagger(New()), multipartRequest(), &request)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, "foo", request.Name) &&
+					assert.Equal(t, []string{"admin", "user"}, request.Roles) &&
+					assert.Equal(t, 18, request.Age) &&
+					assert.Equal(t, "avatar.png", request.Avatar.Filename) &&
+					assert.Equal(t, "content of avatar.png", readFile(request.Avatar)) &&
+					assert.Len(t, request.Files, 2) &&
+					assert.Equal(t, "content of b.txt", readFile(request.Files[1])) &&
+					assert.Nil(t, request.Document)
+			},
+		},
+		{
+			name: "Required parameter",
+			expect: func() bool {
+				var request CreateUser
+				err := Bind(newTagger(New()), httptest.NewRequest(http.MethodPost, "/users", nil), &request)
+
+				return assert.EqualError(t, err, "httpbind: form field name is required")
+			},
+		},
+		{
+			name: "Incorrect value",
+			expect: func() bool {
+				var request GetUsers
+				err := Bind(newTagger(New()), httptest.NewRequest(http.MethodGet, "/users?page=first", nil), &request)
+
+				return assert.ErrorContains(t, err, "httpbind: can't bind query parameter page to the field Pagination.Page")
+			},
+		},
+		{
+			name: "Data must be a request",
+			expect: func() bool {
+				err := newTagger(New()).In("page=1", &GetUsers{}, "")
+
+				return assert.EqualError(t, err, "httpbind: data for In must be *http.Request, got string")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestBind] %s is not true", c.name))
+		}
+	}
+}
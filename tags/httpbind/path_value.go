@@ -0,0 +1,10 @@
+//go:build go1.22
+
+package httpbind
+
+import "net/http"
+
+// pathValue reads the path parameter matched by http.ServeMux
+func pathValue(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
@@ -0,0 +1,10 @@
+//go:build !go1.22
+
+package httpbind
+
+import "net/http"
+
+// pathValue http.ServeMux doesn't match path parameters before Go 1.22
+func pathValue(r *http.Request, name string) string {
+	return ""
+}
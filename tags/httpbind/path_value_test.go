@@ -0,0 +1,40 @@
+//go:build go1.22
+
+package httpbind
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBind_PathValue(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Path parameters of http.ServeMux",
+			expect: func() bool {
+				r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+				r.SetPathValue("id", "42")
+
+				var request GetUsers
+				err := Bind(newTagger(New()), r, &request)
+
+				return assert.NoError(t, err) && assert.Equal(t, int64(42), request.ID)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestBind_PathValue] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package httpbind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/internal/convert"
+	"github.com/shindakioku/tagger/internal/fields"
+)
+
+var (
+	cookieType    = reflect.TypeOf(http.Cookie{})
+	cookiePtrType = reflect.TypeOf((*http.Cookie)(nil))
+	timeType      = reflect.TypeOf(time.Time{})
+)
+
+// Response collects headers and the status by Out, so they are written in the right order
+// (a status written to http.ResponseWriter before headers discards them)
+//
+//	type UserResponse struct {
+//	  ETag     string    `header:"ETag"`
+//	  Modified time.Time `header:"Last-Modified"`
+//	  Session  string    `cookie:"sid,httponly,secure,path=/,maxage=3600,samesite=lax"`
+//	  Status   int       `status:""`
+//	}
+type Response struct {
+	Status int
+	Header http.Header
+}
+
+// NewResponse initialize of a response
+func NewResponse() *Response {
+	return &Response{Header: make(http.Header)}
+}
+
+// Apply writes the headers (replacing values of the writer, cookies are added) and the status if it's defined
+func (r *Response) Apply(w http.ResponseWriter) {
+	for key, values := range r.Header {
+		if key == "Set-Cookie" {
+			w.Header()[key] = append(w.Header()[key], values...)
+
+			continue
+		}
+
+		w.Header()[key] = append([]string(nil), values...)
+	}
+
+	if r.Status != 0 {
+		w.WriteHeader(r.Status)
+	}
+}
+
+// Write exports headers, cookies and the status of the struct to the response writer
+func Write(t tagger.Tagger, w http.ResponseWriter, v any, tags ...string) error {
+	response := NewResponse()
+	if _, err := t.Out(response, v, "", tags...); err != nil {
+		return err
+	}
+	response.Apply(w)
+
+	return nil
+}
+
+// writer writes value of the field to the headers of the response
+type writer func(header http.Header, name string, value reflect.Value, fieldOptions options) error
+
+// write Out handler of the tag. Data is *Response, http.Header or http.ResponseWriter.
+func (s source) write(data any, field *tagger.Field, _ *reflect.Value) (any, error) {
+	header, err := headerOf(data)
+	if err != nil {
+		return data, err
+	}
+
+	fieldOptions := optionsOf(field, s.name)
+	if fieldOptions.skip {
+		return data, nil
+	}
+
+	value := fields.Value(field)
+	isCookie := value.Type() == cookieType || value.Type() == cookiePtrType
+	// Fields of nested structs are exported by their tags
+	if field.IsStruct && !convert.IsScalar(field.StructField.Type) && !isCookie {
+		return data, nil
+	}
+
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return data, nil
+	}
+
+	if fieldOptions.rest.Exists("omitempty") && value.IsZero() {
+		return data, nil
+	}
+
+	if err = s.out(header, fieldOptions.name, value, fieldOptions); err != nil {
+		return data, errors.New(fmt.Sprintf("httpbind: can't write %s %s of the field %s: %s", s.description, fieldOptions.name, field.Path(), err))
+	}
+
+	return data, nil
+}
+
+func headerOf(data any) (http.Header, error) {
+	switch output := data.(type) {
+	case *Response:
+		if output.Header == nil {
+			output.Header = make(http.Header)
+		}
+
+		return output.Header, nil
+	case http.Header:
+		return output, nil
+	case http.ResponseWriter:
+		return output.Header(), nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("httpbind: data for Out must be *Response, http.Header or http.ResponseWriter, got %T", data))
+}
+
+// writeHeader writes values of the field (every element of a slice). Time is written in the HTTP format.
+func writeHeader(header http.Header, name string, value reflect.Value, _ options) error {
+	var values []string
+	if value.Type() == timeType {
+		values = []string{value.Interface().(time.Time).UTC().Format(http.TimeFormat)}
+	} else {
+		var err error
+		if values, err = convert.Strings(value, ""); err != nil {
+			return err
+		}
+	}
+
+	header.Del(name)
+	for _, v := range values {
+		header.Add(name, v)
+	}
+
+	return nil
+}
+
+// writeCookie writes the field as a cookie with attributes from the tag: path, domain, maxage, samesite,
+// httponly and secure. http.Cookie fields are written as they are (attributes of the tag are added).
+func writeCookie(header http.Header, name string, value reflect.Value, fieldOptions options) error {
+	cookie := &http.Cookie{Name: name}
+	switch value.Type() {
+	case cookieType:
+		*cookie = value.Interface().(http.Cookie)
+	case cookiePtrType:
+		*cookie = *value.Interface().(*http.Cookie)
+	default:
+		formatted, err := convert.String(value, "")
+		if err != nil {
+			return err
+		}
+		cookie.Value = formatted
+	}
+
+	if len(cookie.Name) == 0 {
+		cookie.Name = name
+	}
+
+	if err := applyCookieOptions(cookie, fieldOptions.rest); err != nil {
+		return err
+	}
+
+	if err := cookie.Valid(); err != nil {
+		return err
+	}
+	header.Add("Set-Cookie", cookie.String())
+
+	return nil
+}
+
+func applyCookieOptions(cookie *http.Cookie, fieldTag tagger.FieldTag) error {
+	if path, exists := fieldTag.FindByKey("path"); exists {
+		cookie.Path = path
+	}
+
+	if domain, exists := fieldTag.FindByKey("domain"); exists {
+		cookie.Domain = domain
+	}
+
+	if maxAge, exists := fieldTag.FindByKey("maxage"); exists {
+		parsed, err := strconv.Atoi(maxAge)
+		if err != nil {
+			return errors.New(fmt.Sprintf("incorrect maxage %s", maxAge))
+		}
+		cookie.MaxAge = parsed
+	}
+
+	if sameSite, exists := fieldTag.FindByKey("samesite"); exists {
+		switch strings.ToLower(sameSite) {
+		case "lax":
+			cookie.SameSite = http.SameSiteLaxMode
+		case "strict":
+			cookie.SameSite = http.SameSiteStrictMode
+		case "none":
+			cookie.SameSite = http.SameSiteNoneMode
+		default:
+			return errors.New(fmt.Sprintf("incorrect samesite %s", sameSite))
+		}
+	}
+
+	cookie.HttpOnly = cookie.HttpOnly || fieldTag.Exists("httponly")
+	cookie.Secure = cookie.Secure || fieldTag.Exists("secure")
+
+	return nil
+}
+
+// writeStatus Out handler of the status tag. Zero status isn't written.
+// Status is written to http.ResponseWriter immediately, so it must be the last field or Write must be used.
+func writeStatus(data any, field *tagger.Field, _ *reflect.Value) (any, error) {
+	value := fields.Value(field)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return data, nil
+		}
+		value = value.Elem()
+	}
+
+	var status int
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		status = int(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		status = int(value.Uint())
+	default:
+		return data, errors.New(fmt.Sprintf("httpbind: status must be an integer (field %s)", field.Path()))
+	}
+
+	if status == 0 {
+		return data, nil
+	}
+
+	switch output := data.(type) {
+	case *Response:
+		output.Status = status
+	case http.ResponseWriter:
+		output.WriteHeader(status)
+	default:
+		return data, errors.New(fmt.Sprintf("httpbind: data for the status must be *Response or http.ResponseWriter, got %T", data))
+	}
+
+	return data, nil
+}
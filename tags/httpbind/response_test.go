@@ -0,0 +1,121 @@
+package httpbind
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Cache struct {
+	ETag     string    `header:"ETag"`
+	Modified time.Time `header:"Last-Modified"`
+}
+
+type UserResponse struct {
+	Status   int          `status:""`
+	Vary     []string     `header:"Vary"`
+	Location *string      `header:"Location"`
+	Warning  string       `header:"Warning,omitempty"`
+	Session  string       `cookie:"sid,httponly,secure,path=/,maxage=3600,samesite=lax"`
+	Theme    *http.Cookie `cookie:"theme"`
+	Cache    Cache
+	Name     string
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+2", 2*60*60))
+	response := UserResponse{
+		Status:  http.StatusCreated,
+		Vary:    []string{"Accept", "Cookie"},
+		Session: "session",
+		Theme:   &http.Cookie{Value: "dark"},
+		Cache:   Cache{ETag: `"v1"`, Modified: modified},
+		Name:    "foo",
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Headers, cookies and the status",
+			expect: func() bool {
+				recorder := httptest.NewRecorder()
+				recorder.Header().Set("Vary", "Origin")
+				err := Write(newTagger(New()), recorder, &response)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, http.StatusCreated, recorder.Code) &&
+					assert.Equal(t, []string{"Accept", "Cookie"}, recorder.Header().Values("Vary")) &&
+					assert.Equal(t, `"v1"`, recorder.Header().Get("ETag")) &&
+					assert.Equal(t, "Tue, 02 Jan 2024 01:04:05 GMT", recorder.Header().Get("Last-Modified")) &&
+					assert.Empty(t, recorder.Header().Values("Location")) &&
+					assert.Empty(t, recorder.Header().Values("Warning")) &&
+					assert.Equal(t, []string{
+						"sid=session; Path=/; Max-Age=3600; HttpOnly; Secure; SameSite=Lax",
+						"theme=dark",
+					}, recorder.Header().Values("Set-Cookie"))
+			},
+		},
+		{
+			name: "http.Header",
+			expect: func() bool {
+				header := make(http.Header)
+				_, err := newTagger(New()).Out(header, &struct {
+					ETag string `header:"ETag"`
+				}{ETag: "v2"}, "")
+
+				return assert.NoError(t, err) && assert.Equal(t, "v2", header.Get("ETag"))
+			},
+		},
+		{
+			name: "Status can't be written to http.Header",
+			expect: func() bool {
+				_, err := newTagger(New()).Out(make(http.Header), &response, "")
+
+				return assert.EqualError(t, err, "httpbind: data for the status must be *Response or http.ResponseWriter, got http.Header")
+			},
+		},
+		{
+			name: "Headers read by In are written by Out",
+			expect: func() bool {
+				recorder := httptest.NewRecorder()
+				if !assert.NoError(t, Write(newTagger(New()), recorder, &response)) {
+					return false
+				}
+
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				r.Header = recorder.Header()
+
+				var cache Cache
+				err := Bind(newTagger(New()), r, &cache)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, response.Cache.ETag, cache.ETag) &&
+					assert.True(t, response.Cache.Modified.Equal(cache.Modified))
+			},
+		},
+		{
+			name: "Incorrect cookie",
+			expect: func() bool {
+				_, err := newTagger(New()).Out(NewResponse(), &struct {
+					Session string `cookie:"sid,samesite=always"`
+				}{Session: "session"}, "")
+
+				return assert.EqualError(t, err, "httpbind: can't write cookie sid of the field Session: incorrect samesite always")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestWrite] %s is not true", c.name))
+		}
+	}
+}
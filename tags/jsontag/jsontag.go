@@ -0,0 +1,350 @@
+// Package jsontag provides the "json" tag which fills structs from JSON by In and exports them by Out
+// with the semantics of encoding/json: `json:"name"`, `json:"name,omitempty"`, `json:"id,string"`, `json:"-"`
+// and `json:",inline"` (embedded structs without names are inline too).
+//
+// Fields are processed by the tagger, so the tag can be combined with other tags on the same struct:
+//
+//	t := tagger.NewReflectionTagger().Add(jsontag.New()).Add(defaultTag).Add(validateTag)
+//	err := jsontag.Unmarshal(t, body, &user)
+//	bytes, err := jsontag.Marshal(t, &user)
+package jsontag
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/shindakioku/tagger"
+)
+
+// Name of the tag
+const Name = "json"
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// New returns the "json" tag.
+// In accepts decoded object (map[string]any), []byte, json.RawMessage or string (decoded for every field,
+// so Unmarshal is preferred). Out accepts map[string]any and fills it.
+func New() *tagger.Tag {
+	return NewTag(Name)
+}
+
+// NewTag returns the tag with the json semantics under another name: NewTag("api") for `api:"user_id"`
+func NewTag(name string) *tagger.Tag {
+	c := &codec{tag: tagger.New(name).Symbols("", ",").ForFieldsWithoutKey()}
+
+	return c.tag.InFunction(c.in).OutFunction(c.out)
+}
+
+// Unmarshal decodes JSON ([]byte, json.RawMessage, string, io.Reader or map[string]any) once and fills the struct.
+// Fields without the "json" key are filled by the "json" tag (by names of the fields) as encoding/json does.
+func Unmarshal(t tagger.Tagger, data any, v any, tags ...string) error {
+	object, err := Decode(data)
+	if err != nil {
+		return err
+	}
+
+	return t.In(object, v, Name, tags...)
+}
+
+// Marshal exports the struct to JSON. Keys of the objects are sorted (as for any map in encoding/json).
+// Fields without the "json" key are exported by the "json" tag (by names of the fields) as encoding/json does.
+func Marshal(t tagger.Tagger, v any, tags ...string) ([]byte, error) {
+	output, err := t.Out(map[string]any{}, v, Name, tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(output)
+}
+
+// Decode returns decoded JSON object. Numbers are decoded as json.Number for to keep precision.
+func Decode(data any) (map[string]any, error) {
+	var reader io.Reader
+	switch source := data.(type) {
+	case map[string]any:
+		return source, nil
+	case []byte:
+		reader = bytes.NewReader(source)
+	case json.RawMessage:
+		reader = bytes.NewReader(source)
+	case string:
+		reader = strings.NewReader(source)
+	case io.Reader:
+		reader = source
+	default:
+		return nil, errors.New(fmt.Sprintf("json: unsupported data %T", data))
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, errors.New(fmt.Sprintf("json: can't decode data: %s", err))
+	}
+
+	return object, nil
+}
+
+type codec struct {
+	tag *tagger.Tag
+}
+
+// options of the field from the tag
+type options struct {
+	name      string
+	skip      bool
+	omitEmpty bool
+	asString  bool
+	inline    bool
+}
+
+func (c *codec) options(field *tagger.Field) options {
+	var raw string
+	if key, exists := c.tag.Lookup(field.StructField.Tag); exists {
+		raw = field.StructField.Tag.Get(key)
+	}
+
+	if raw == "-" {
+		return options{skip: true}
+	}
+
+	parts := strings.Split(raw, ",")
+	output := options{name: parts[0]}
+	if output.name != "-" {
+		output.name = field.Key(c.tag.Name)
+	}
+
+	for _, option := range parts[1:] {
+		switch option {
+		case "omitempty":
+			output.omitEmpty = true
+		case "string":
+			output.asString = true
+		case "inline":
+			output.inline = true
+		}
+	}
+
+	// Embedded struct without name
+	if field.StructField.Anonymous && len(parts[0]) == 0 && field.IsStruct {
+		output.inline = true
+	}
+
+	return output
+}
+
+// ancestors returns parent fields from the root struct
+func ancestors(field *tagger.Field) (fields []*tagger.Field) {
+	for parent := field.ParentStruct; parent != nil && parent.ParentField != nil; parent = parent.ParentField.ParentStruct {
+		fields = append([]*tagger.Field{parent.ParentField}, fields...)
+	}
+
+	return
+}
+
+// objectOf returns object of the struct which contains the field. Nested objects are created if create is true.
+func (c *codec) objectOf(root map[string]any, field *tagger.Field, create bool) (map[string]any, bool) {
+	object := root
+	for _, parent := range ancestors(field) {
+		parentOptions := c.options(parent)
+		if parentOptions.skip {
+			return nil, false
+		}
+
+		if parentOptions.inline {
+			continue
+		}
+
+		value, exists := lookup(object, parentOptions.name)
+		nested, ok := value.(map[string]any)
+		if !ok {
+			if exists || !create {
+				return nil, false
+			}
+
+			nested = make(map[string]any)
+			object[parentOptions.name] = nested
+		}
+		object = nested
+	}
+
+	return object, true
+}
+
+// lookup returns value by the key (case-insensitive if there is no exact key)
+func lookup(object map[string]any, key string) (any, bool) {
+	if value, exists := object[key]; exists {
+		return value, true
+	}
+
+	for k, value := range object {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func implements(typeOf reflect.Type, interfaces ...reflect.Type) bool {
+	for _, i := range interfaces {
+		if typeOf.Implements(i) || reflect.PtrTo(typeOf).Implements(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *codec) in(data any, field *tagger.Field, _ *reflect.Value) error {
+	fieldOptions := c.options(field)
+	if fieldOptions.skip || fieldOptions.inline {
+		return nil
+	}
+
+	if _, isReader := data.(io.Reader); isReader {
+		return errors.New("json: io.Reader can be read only once, use jsontag.Unmarshal")
+	}
+
+	root, err := Decode(data)
+	if err != nil {
+		return err
+	}
+
+	var value any
+	object, exists := c.objectOf(root, field, false)
+	if exists {
+		value, exists = lookup(object, fieldOptions.name)
+	}
+
+	// Fields of nested structs are filled by their handlers
+	if field.IsStruct && !implements(field.StructField.Type, jsonUnmarshalerType, textUnmarshalerType) {
+		// Pointer to the nested struct stays nil if the object is missing or null (as in encoding/json)
+		if field.StructField.Type.Kind() == reflect.Ptr && (!exists || value == nil) {
+			field.Value.Set(reflect.Zero(field.Value.Type()))
+		}
+
+		return nil
+	}
+
+	// In allocates pointers, a missing key leaves nil (as in encoding/json)
+	if !exists {
+		if field.StructField.Type.Kind() == reflect.Ptr {
+			field.Value.Set(reflect.Zero(field.Value.Type()))
+		}
+
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if fieldOptions.asString && value != nil {
+		literal, ok := value.(string)
+		if !ok {
+			return errors.New(fmt.Sprintf("json: expected string for the field %s with the string option", field.Path()))
+		}
+		raw = []byte(literal)
+	}
+
+	target := field.Value
+	if target.CanAddr() {
+		target = target.Addr()
+	}
+
+	if err = json.Unmarshal(raw, target.Interface()); err != nil {
+		return errors.New(fmt.Sprintf("json: can't decode the field %s: %s", field.Path(), err))
+	}
+
+	return nil
+}
+
+func (c *codec) out(data any, field *tagger.Field, _ *reflect.Value) (any, error) {
+	root, ok := data.(map[string]any)
+	if !ok {
+		return data, errors.New(fmt.Sprintf("json: data for Out must be map[string]any, got %T", data))
+	}
+
+	fieldOptions := c.options(field)
+	if fieldOptions.skip || fieldOptions.inline {
+		return data, nil
+	}
+
+	object, exists := c.objectOf(root, field, true)
+	if !exists {
+		return data, nil
+	}
+
+	isNil := field.Value.Kind() == reflect.Ptr && field.Value.IsNil()
+	if fieldOptions.omitEmpty && isEmptyValue(field.Value) {
+		return data, nil
+	}
+
+	// Fields of nested structs are exported by their handlers
+	if field.IsStruct && !isNil && !implements(field.StructField.Type, jsonMarshalerType, textMarshalerType) {
+		if _, exists = object[fieldOptions.name]; !exists {
+			object[fieldOptions.name] = make(map[string]any)
+		}
+
+		return data, nil
+	}
+
+	value := field.Value.Interface()
+	if fieldOptions.asString && !isNil && isQuotable(field.Value) {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return data, err
+		}
+		value = string(raw)
+	}
+	object[fieldOptions.name] = value
+
+	return data, nil
+}
+
+// isEmptyValue the same as in encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+
+	return false
+}
+
+// isQuotable the string option is applied only to strings, numbers and booleans (and pointers to them)
+func isQuotable(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,240 @@
+package jsontag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shindakioku/tagger"
+	"github.com/stretchr/testify/assert"
+)
+
+type Base struct {
+	ID int64 `json:"id,string"`
+}
+
+type Address struct {
+	City   string `json:"city"`
+	Street string `json:"street,omitempty"`
+}
+
+type User struct {
+	Base
+	Name      string         `json:"name" validate:"required"`
+	Email     *string        `json:"email,omitempty"`
+	Password  string         `json:"-"`
+	Tags      []string       `json:"tags,omitempty"`
+	Meta      map[string]any `json:"meta,omitempty"`
+	Address   Address        `json:"address"`
+	Billing   *Address       `json:"billing"`
+	Extra     Address        `json:",inline"`
+	CreatedAt time.Time      `json:"created_at"`
+	Scores    map[string]int `json:"scores,omitempty"`
+	Nickname  string
+	Ignored   string          `json:"-"`
+	Raw       json.RawMessage `json:"raw,omitempty"`
+}
+
+// validate tag on the same struct
+func newTagger() tagger.Tagger {
+	return tagger.NewReflectionTagger().
+		Add(New()).
+		Add(tagger.New("validate").InFunction(func(data any, field *tagger.Field, in *reflect.Value) error {
+			if field.Get() == "" {
+				return errors.New(fmt.Sprintf("%s is required", field.Path()))
+			}
+
+			return nil
+		}))
+}
+
+const body = `{
+	"id": "42",
+	"name": "foo",
+	"email": "foo@mail.com",
+	"password": "secret",
+	"tags": ["a", "b\"c"],
+	"meta": {"nested": {"level": 2}},
+	"address": {"city": "Kyiv", "street": "Main \"1\""},
+	"billing": {"city": "Lviv"},
+	"city": "Odesa",
+	"created_at": "2024-01-02T03:04:05Z",
+	"NICKNAME": "nick"
+}`
+
+func TestUnmarshal(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   any
+		expect func(user User, err error) bool
+	}{
+		{
+			name: "Test JSON bytes",
+			data: []byte(body),
+			expect: func(user User, err error) bool {
+				email := "foo@mail.com"
+
+				return assert.Nil(t, err) &&
+					assert.Equal(t, int64(42), user.ID) &&
+					assert.Equal(t, "foo", user.Name) &&
+					assert.Equal(t, &email, user.Email) &&
+					assert.Empty(t, user.Password) &&
+					assert.Equal(t, []string{"a", "b\"c"}, user.Tags) &&
+					assert.Equal(t, map[string]any{"nested": map[string]any{"level": float64(2)}}, user.Meta) &&
+					assert.Equal(t, Address{City: "Kyiv", Street: "Main \"1\""}, user.Address) &&
+					assert.Equal(t, &Address{City: "Lviv"}, user.Billing) &&
+					assert.Equal(t, "Odesa", user.Extra.City) &&
+					assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), user.CreatedAt) &&
+					assert.Equal(t, "nick", user.Nickname)
+			},
+		},
+		{
+			name: "Test reader and decoded object",
+			data: strings.NewReader(`{"name": "reader", "billing": null}`),
+			expect: func(user User, err error) bool {
+				return assert.Nil(t, err) && assert.Equal(t, "reader", user.Name)
+			},
+		},
+		{
+			name: "Test validate tag on the same field",
+			data: map[string]any{"name": ""},
+			expect: func(user User, err error) bool {
+				return assert.EqualError(t, err, "Name is required")
+			},
+		},
+		{
+			name: "Test incorrect value",
+			data: `{"name": "foo", "address": {"city": 1}}`,
+			expect: func(user User, err error) bool {
+				return assert.ErrorContains(t, err, "json: can't decode the field Address.City")
+			},
+		},
+		{
+			name: "Test incorrect JSON",
+			data: `{"name": `,
+			expect: func(user User, err error) bool {
+				return assert.ErrorContains(t, err, "json: can't decode data")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		user := User{}
+		if !c.expect(user, Unmarshal(newTagger(), c.data, &user)) {
+			t.Error(fmt.Sprintf("[TestUnmarshal] %s is not true", c.name))
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	cases := []struct {
+		name   string
+		user   User
+		expect string
+	}{
+		{
+			name: "Test struct with empty fields",
+			user: User{Base: Base{ID: 1}, Name: "foo", Password: "secret"},
+			expect: `{"address":{"city":""},"billing":null,"city":"","created_at":"0001-01-01T00:00:00Z",` +
+				`"id":"1","name":"foo","Nickname":""}`,
+		},
+		{
+			name: "Test struct with all fields",
+			user: User{
+				Name:      "foo",
+				Tags:      []string{"a"},
+				Address:   Address{City: "Kyiv", Street: "Main"},
+				Billing:   &Address{City: "Lviv"},
+				Extra:     Address{City: "Odesa"},
+				CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Nickname:  "nick",
+				Raw:       json.RawMessage(`[1,2]`),
+			},
+			expect: `{"address":{"city":"Kyiv","street":"Main"},"billing":{"city":"Lviv"},"city":"Odesa",` +
+				`"created_at":"2024-01-02T03:04:05Z","id":"0","name":"foo","Nickname":"nick","raw":[1,2],"tags":["a"]}`,
+		},
+	}
+
+	for _, c := range cases {
+		output, err := Marshal(newTagger(), &c.user)
+		if !assert.Nil(t, err) || !assert.JSONEq(t, c.expect, string(output)) {
+			t.Error(fmt.Sprintf("[TestMarshal] %s is not true", c.name))
+		}
+	}
+}
+
+// fields with other tags only are handled by their names
+type Account struct {
+	Login string `validate:"required"`
+	Note  string `xml:"note"`
+}
+
+func TestFieldsWithoutKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Test unmarshal fields with other tags",
+			expect: func() bool {
+				account := Account{}
+				err := Unmarshal(newTagger(), `{"login": "foo", "note": "bar"}`, &account)
+
+				return assert.Nil(t, err) && assert.Equal(t, Account{Login: "foo", Note: "bar"}, account)
+			},
+		},
+		{
+			name: "Test other tags are handled too",
+			expect: func() bool {
+				return assert.EqualError(t, Unmarshal(newTagger(), `{"note": "bar"}`, &Account{}), "Login is required")
+			},
+		},
+		{
+			name: "Test marshal fields with other tags",
+			expect: func() bool {
+				output, err := Marshal(newTagger(), &Account{Login: "foo", Note: "bar"})
+
+				return assert.Nil(t, err) && assert.JSONEq(t, `{"Login":"foo","Note":"bar"}`, string(output))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestFieldsWithoutKey] %s is not true", c.name))
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	email := "foo@mail.com"
+	cases := []struct {
+		name string
+		user User
+	}{
+		{
+			name: "Test round trip",
+			user: User{Base: Base{ID: 7}, Name: "foo", Email: &email, Address: Address{City: "Kyiv"}},
+		},
+		{
+			name: "Test round trip with missing pointer keys",
+			user: User{Base: Base{ID: 7}, Name: "foo", Address: Address{City: "Kyiv"}},
+		},
+	}
+
+	for _, c := range cases {
+		output, err := Marshal(newTagger(), &c.user)
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		decoded := User{}
+		if !assert.Nil(t, Unmarshal(newTagger(), output, &decoded)) || !assert.Equal(t, c.user, decoded) {
+			t.Error(fmt.Sprintf("[TestRoundTrip] %s is not true", c.name))
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Package sqltag provides the "db" tag which fills structs from *sql.Rows by In
+// and exports columns and arguments for INSERT/UPDATE by Out.
+//
+// Options of the tag: `db:"id,pk"` is the primary key (Update uses it in WHERE),
+// `db:"created_at,readonly"` is filled by In but never written, `db:"email,omitempty"` isn't written if it's zero.
+// Values are scanned by database/sql, so sql.Null* types and sql.Scanner/driver.Valuer types are supported.
+// Fields of nested structs without the tag are columns of the same row.
+//
+//	t := tagger.NewReflectionTagger().Add(sqltag.New())
+//
+//	var users []User
+//	rows, err := db.Query("SELECT * FROM users")
+//	err = sqltag.ScanAll(t, rows, &users)
+//
+//	columns, err := sqltag.Insert(t, &user)
+//	_, err = db.Exec(columns.InsertSQL("users", sqltag.Question), columns.Args...)
+package sqltag
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/internal/fields"
+)
+
+// Name of the tag
+const Name = "db"
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// New returns the "db" tag
+func New() *tagger.Tag {
+	return NewTag(Name)
+}
+
+// NewTag returns the tag under another name: NewTag("sql") for `sql:"user_id"`
+func NewTag(name string) *tagger.Tag {
+	c := &codec{name: name}
+
+	return tagger.New(name).Symbols("", ",").InFunction(c.in).OutFunction(c.out)
+}
+
+// Row destinations for the columns of the current row. Filled by In and scanned by Scan.
+type Row struct {
+	columns []string
+	indexes map[string]int
+	dest    []any
+}
+
+// NewRow returns destinations for the columns of the rows
+func NewRow(rows *sql.Rows) (*Row, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &Row{
+		columns: columns,
+		indexes: make(map[string]int, len(columns)),
+		dest:    make([]any, len(columns)),
+	}
+	for i, column := range columns {
+		column = strings.ToLower(column)
+		if _, exists := row.indexes[column]; !exists {
+			row.indexes[column] = i
+		}
+	}
+
+	return row, nil
+}
+
+// Scan scans the current row to the destinations. Columns without fields are skipped.
+func (r *Row) Scan(rows *sql.Rows) error {
+	dest := make([]any, len(r.dest))
+	for i, d := range r.dest {
+		if d == nil {
+			d = new(any)
+		}
+		dest[i] = d
+	}
+
+	return rows.Scan(dest...)
+}
+
+// Scan fills the struct from the current row (rows.Next must be called before)
+func Scan(t tagger.Tagger, rows *sql.Rows, v any, tags ...string) error {
+	row, err := NewRow(rows)
+	if err != nil {
+		return err
+	}
+
+	if err = t.In(row, v, "", tags...); err != nil {
+		return err
+	}
+
+	return row.Scan(rows)
+}
+
+// ScanAll fills the slice of structs (or pointers to structs) from all the rows and closes them
+//
+//	var users []*User
+//	err := sqltag.ScanAll(t, rows, &users)
+func ScanAll(t tagger.Tagger, rows *sql.Rows, slice any, tags ...string) error {
+	defer rows.Close()
+
+	sliceOf := reflect.ValueOf(slice)
+	if sliceOf.Kind() != reflect.Ptr || sliceOf.Elem().Kind() != reflect.Slice {
+		return errors.New(fmt.Sprintf("sqltag: expected pointer to a slice, got %T", slice))
+	}
+	sliceOf = sliceOf.Elem()
+
+	elemType := sliceOf.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	for rows.Next() {
+		elem := reflect.New(elemType)
+		if err := Scan(t, rows, elem.Interface(), tags...); err != nil {
+			return err
+		}
+
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		sliceOf.Set(reflect.Append(sliceOf, elem))
+	}
+
+	return rows.Err()
+}
+
+// Placeholder returns the placeholder of the argument by the number (from 1)
+type Placeholder func(n int) string
+
+var (
+	// Question ? (MySQL, SQLite)
+	Question Placeholder = func(int) string {
+		return "?"
+	}
+	// Dollar $1 (PostgreSQL)
+	Dollar Placeholder = func(n int) string {
+		return "$" + strconv.Itoa(n)
+	}
+)
+
+// Columns ordered columns of the struct and their arguments for INSERT/UPDATE
+type Columns struct {
+	Names []string
+	Args  []any
+	// Primary key columns and their arguments (only for Update, Insert keeps them in Names)
+	Keys    []string
+	KeyArgs []any
+
+	update bool
+}
+
+// Insert returns all columns of the struct except readonly and empty omitempty ones
+func Insert(t tagger.Tagger, v any, tags ...string) (*Columns, error) {
+	output, err := t.Out(&Columns{}, v, "", tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.(*Columns), nil
+}
+
+// Update the same as Insert, but primary key columns are in Keys
+func Update(t tagger.Tagger, v any, tags ...string) (*Columns, error) {
+	output, err := t.Out(&Columns{update: true}, v, "", tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.(*Columns), nil
+}
+
+// Placeholders returns placeholders of Args
+func (c *Columns) Placeholders(placeholder Placeholder) []string {
+	output := make([]string, len(c.Names))
+	for i := range c.Names {
+		output[i] = placeholder(i + 1)
+	}
+
+	return output
+}
+
+// AllArgs returns Args and then KeyArgs (the order of InsertSQL and UpdateSQL)
+func (c *Columns) AllArgs() []any {
+	return append(append([]any{}, c.Args...), c.KeyArgs...)
+}
+
+// InsertSQL INSERT INTO users (name, email) VALUES (?, ?)
+func (c *Columns) InsertSQL(table string, placeholder Placeholder) string {
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		table,
+		strings.Join(c.Names, ", "),
+		strings.Join(c.Placeholders(placeholder), ", "),
+	)
+}
+
+// UpdateSQL UPDATE users SET name = $1, email = $2 WHERE id = $3
+func (c *Columns) UpdateSQL(table string, placeholder Placeholder) string {
+	assignments := make([]string, len(c.Names))
+	for i, name := range c.Names {
+		assignments[i] = name + " = " + placeholder(i+1)
+	}
+
+	conditions := make([]string, len(c.Keys))
+	for i, key := range c.Keys {
+		conditions[i] = key + " = " + placeholder(len(c.Names)+i+1)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s", table, strings.Join(assignments, ", "))
+	if len(conditions) != 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return query
+}
+
+type codec struct {
+	name string
+}
+
+// options of the field from the tag
+type options struct {
+	name      string
+	skip      bool
+	pk        bool
+	readonly  bool
+	omitEmpty bool
+}
+
+func (c *codec) options(field *tagger.Field) options {
+	parts := field.Tag.Parts()
+	if len(parts) != 0 && parts[0] == "-" {
+		return options{skip: true}
+	}
+
+	output := options{name: field.Key(c.name)}
+	for _, option := range parts[1:] {
+		switch strings.TrimSpace(option) {
+		case "pk":
+			output.pk = true
+		case "readonly":
+			output.readonly = true
+		case "omitempty":
+			output.omitEmpty = true
+		}
+	}
+
+	return output
+}
+
+func (c *codec) in(data any, field *tagger.Field, _ *reflect.Value) error {
+	row, ok := data.(*Row)
+	if !ok {
+		return errors.New(fmt.Sprintf("sqltag: data for In must be *Row, got %T", data))
+	}
+
+	fieldOptions := c.options(field)
+	if fieldOptions.skip || !fields.IsColumn(field, scannerType, valuerType) {
+		return nil
+	}
+
+	index, exists := row.indexes[strings.ToLower(fieldOptions.name)]
+	if !exists {
+		return nil
+	}
+
+	// Pointer to the field itself, so NULL leaves pointers nil
+	row.dest[index] = fields.Value(field).Addr().Interface()
+
+	return nil
+}
+
+func (c *codec) out(data any, field *tagger.Field, _ *reflect.Value) (any, error) {
+	columns, ok := data.(*Columns)
+	if !ok {
+		return data, errors.New(fmt.Sprintf("sqltag: data for Out must be *Columns, got %T", data))
+	}
+
+	fieldOptions := c.options(field)
+	if fieldOptions.skip || fieldOptions.readonly || !fields.IsColumn(field, scannerType, valuerType) {
+		return data, nil
+	}
+
+	value := fields.Value(field)
+	if fieldOptions.omitEmpty && value.IsZero() {
+		return data, nil
+	}
+
+	var arg any
+	if value.Kind() != reflect.Ptr || !value.IsNil() {
+		arg = value.Interface()
+	}
+
+	if columns.update && fieldOptions.pk {
+		columns.Keys = append(columns.Keys, fieldOptions.name)
+		columns.KeyArgs = append(columns.KeyArgs, arg)
+	} else {
+		columns.Names = append(columns.Names, fieldOptions.name)
+		columns.Args = append(columns.Args, arg)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,332 @@
+package sqltag
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shindakioku/tagger"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver returns rows of the tables by queries "SELECT <table>" and records executed statements
+type fakeDriver struct {
+	mu       sync.Mutex
+	tables   map[string]*fakeRows
+	executed []fakeExec
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+type fakeStmt struct {
+	conn  fakeConn
+	query string
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	index   int
+}
+
+var fake = &fakeDriver{
+	tables: map[string]*fakeRows{
+		"users": {
+			columns: []string{"id", "Name", "email", "nickname", "tags", "created_at", "street", "unknown"},
+			values: [][]driver.Value{
+				{int64(1), "foo", "foo@mail.com", "f", "a,b", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "Main", int64(0)},
+				{int64(2), "bar", nil, nil, "", time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), nil, nil},
+			},
+		},
+	},
+}
+
+func init() {
+	sql.Register("sqltag_fake", fake)
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{driver: d}, nil
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{conn: c, query: query}, nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+
+	s.conn.driver.executed = append(s.conn.driver.executed, fakeExec{query: s.query, args: args})
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	table, exists := s.conn.driver.tables[strings.TrimPrefix(s.query, "SELECT ")]
+	if !exists {
+		return nil, errors.New("unknown table")
+	}
+
+	return &fakeRows{columns: table.columns, values: table.values}, nil
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.values) {
+		return io.EOF
+	}
+
+	copy(dest, r.values[r.index])
+	r.index++
+
+	return nil
+}
+
+// Tags sql.Scanner and driver.Valuer
+type Tags []string
+
+func (t *Tags) Scan(src any) error {
+	raw, ok := src.(string)
+	if !ok {
+		return errors.New(fmt.Sprintf("unexpected tags %T", src))
+	}
+
+	*t = nil
+	if len(raw) != 0 {
+		*t = strings.Split(raw, ",")
+	}
+
+	return nil
+}
+
+func (t Tags) Value() (driver.Value, error) {
+	return strings.Join(t, ","), nil
+}
+
+type Address struct {
+	Street sql.NullString `db:"street"`
+}
+
+type User struct {
+	ID        int64          `db:"id,pk"`
+	Name      string         `db:"name"`
+	Email     *string        `db:"email,omitempty"`
+	Nickname  sql.NullString `db:"nickname"`
+	Tags      Tags           `db:"tags"`
+	CreatedAt time.Time      `db:"created_at,readonly"`
+	Password  string         `db:"-"`
+	Note      string
+	Address   Address
+}
+
+func newTagger() tagger.Tagger {
+	return tagger.NewReflectionTagger().Add(New())
+}
+
+func query(table string) *sql.Rows {
+	db, err := sql.Open("sqltag_fake", "")
+	if err != nil {
+		panic(err)
+	}
+
+	rows, err := db.Query("SELECT " + table)
+	if err != nil {
+		panic(err)
+	}
+
+	return rows
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "ScanAll",
+			expect: func() bool {
+				var users []User
+				err := ScanAll(newTagger(), query("users"), &users)
+				if !assert.NoError(t, err) || !assert.Len(t, users, 2) {
+					return false
+				}
+
+				first, second := users[0], users[1]
+
+				return assert.Equal(t, int64(1), first.ID) &&
+					assert.Equal(t, "foo", first.Name) &&
+					assert.Equal(t, "foo@mail.com", *first.Email) &&
+					assert.Equal(t, sql.NullString{String: "f", Valid: true}, first.Nickname) &&
+					assert.Equal(t, Tags{"a", "b"}, first.Tags) &&
+					assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), first.CreatedAt) &&
+					assert.Equal(t, sql.NullString{String: "Main", Valid: true}, first.Address.Street) &&
+					assert.Empty(t, first.Note) &&
+					assert.Equal(t, "bar", second.Name) &&
+					assert.Nil(t, second.Email) &&
+					assert.False(t, second.Nickname.Valid) &&
+					assert.Nil(t, second.Tags) &&
+					assert.False(t, second.Address.Street.Valid)
+			},
+		},
+		{
+			name: "ScanAll to pointers",
+			expect: func() bool {
+				var users []*User
+				err := ScanAll(newTagger(), query("users"), &users)
+
+				return assert.NoError(t, err) &&
+					assert.Len(t, users, 2) &&
+					assert.Equal(t, "bar", users[1].Name)
+			},
+		},
+		{
+			name: "Scan",
+			expect: func() bool {
+				rows := query("users")
+				defer rows.Close()
+
+				var user User
+				if !rows.Next() {
+					return false
+				}
+				err := Scan(newTagger(), rows, &user)
+
+				return assert.NoError(t, err) && assert.Equal(t, int64(1), user.ID)
+			},
+		},
+		{
+			name: "ScanAll expects a slice",
+			expect: func() bool {
+				return assert.EqualError(t, ScanAll(newTagger(), query("users"), &User{}), "sqltag: expected pointer to a slice, got *sqltag.User")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestScan] %s is not true", c.name))
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	t.Parallel()
+
+	email := "foo@mail.com"
+	user := User{
+		ID:        1,
+		Name:      "foo",
+		Email:     &email,
+		Tags:      Tags{"a", "b"},
+		CreatedAt: time.Now(),
+		Password:  "secret",
+		Address:   Address{Street: sql.NullString{String: "Main", Valid: true}},
+	}
+
+	cases := []struct {
+		name   string
+		expect func() bool
+	}{
+		{
+			name: "Insert",
+			expect: func() bool {
+				columns, err := Insert(newTagger(), &user)
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, []string{"id", "name", "email", "nickname", "tags", "street"}, columns.Names) &&
+					assert.Equal(t, []any{int64(1), "foo", &email, sql.NullString{}, Tags{"a", "b"}, user.Address.Street}, columns.Args) &&
+					assert.Equal(t, []string{"$1", "$2", "$3", "$4", "$5", "$6"}, columns.Placeholders(Dollar)) &&
+					assert.Equal(t,
+						"INSERT INTO users (id, name, email, nickname, tags, street) VALUES (?, ?, ?, ?, ?, ?)",
+						columns.InsertSQL("users", Question),
+					)
+			},
+		},
+		{
+			name: "Update",
+			expect: func() bool {
+				columns, err := Update(newTagger(), &User{ID: 2, Name: "bar"})
+
+				return assert.NoError(t, err) &&
+					assert.Equal(t, []string{"name", "nickname", "tags", "street"}, columns.Names) &&
+					assert.Equal(t, []string{"id"}, columns.Keys) &&
+					assert.Equal(t, []any{int64(2)}, columns.KeyArgs) &&
+					assert.Equal(t, []any{"bar", sql.NullString{}, Tags(nil), sql.NullString{}, int64(2)}, columns.AllArgs()) &&
+					assert.Equal(t,
+						"UPDATE users SET name = $1, nickname = $2, tags = $3, street = $4 WHERE id = $5",
+						columns.UpdateSQL("users", Dollar),
+					)
+			},
+		},
+		{
+			name: "Arguments are accepted by the driver",
+			expect: func() bool {
+				columns, err := Insert(newTagger(), &user)
+				if !assert.NoError(t, err) {
+					return false
+				}
+
+				db, _ := sql.Open("sqltag_fake", "")
+				query := columns.InsertSQL("accounts", Question)
+				if _, err = db.Exec(query, columns.Args...); !assert.NoError(t, err) {
+					return false
+				}
+
+				fake.mu.Lock()
+				defer fake.mu.Unlock()
+				for _, executed := range fake.executed {
+					if executed.query == query {
+						return assert.Equal(t, []driver.Value{int64(1), "foo", email, nil, "a,b", "Main"}, executed.args)
+					}
+				}
+
+				return false
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if !c.expect() {
+			t.Error(fmt.Sprintf("[TestColumns] %s is not true", c.name))
+		}
+	}
+}
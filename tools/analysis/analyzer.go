@@ -0,0 +1,425 @@
+// Package analysis provides an analyzer which checks struct tags of the tagger tags.
+//
+// The analyzer reports malformed tags (by the tag symbols), misspelled names of the tags (all unregistered tags
+// with -unregistered), unknown keys of the tags with declared options, duplicated keys,
+// values which don't match declared options, conflicting tags and tags defined on fields of unsupported kinds.
+//
+// Tags are described by the JSON config (see Config):
+//
+//	go vet -vettool=$(which tagger-vet) -taggerlint.config=tags.json ./...
+//
+// or by the same tags which are registered in a tagger:
+//
+//	unitchecker.Main(analysis.NewAnalyzer(myJsonTag, myLoggerTag))
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/tools/internal/source"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check struct tags of the tagger tags
+
+Reports malformed tags, misspelled or unregistered tags, unknown and duplicated keys,
+values which don't match declared options, conflicting tags and tags on fields of unsupported kinds.`
+
+// Analyzer checks tags described by the JSON config (-config flag)
+var Analyzer = NewAnalyzer()
+
+// checker state of an analyzer
+type checker struct {
+	tags []*tagger.Tag
+	// Path of the JSON config
+	config string
+	// Names of other tags which aren't reported (json, db, ...)
+	known []string
+	// Report all tags which aren't registered, not only misspelled names of the registered tags
+	unregistered bool
+
+	once      sync.Once
+	configErr error
+}
+
+// NewAnalyzer creates an analyzer for the tags. Tags from the -config flag are added to them.
+func NewAnalyzer(tags ...*tagger.Tag) *analysis.Analyzer {
+	c := &checker{tags: tags}
+	analyzer := &analysis.Analyzer{
+		Name:     "taggerlint",
+		Doc:      doc,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+	analyzer.Flags.StringVar(&c.config, "config", "", "path to the JSON file with described tags")
+	analyzer.Flags.BoolVar(&c.unregistered, "unregistered", false, "report all tags which aren't registered (not only misspelled)")
+
+	return analyzer
+}
+
+// loadConfig adds tags from the config (only once)
+func (c *checker) loadConfig() error {
+	c.once.Do(func() {
+		if len(c.config) == 0 {
+			return
+		}
+
+		config, err := LoadConfig(c.config)
+		if err != nil {
+			c.configErr = err
+
+			return
+		}
+
+		tags, err := config.Build()
+		if err != nil {
+			c.configErr = err
+
+			return
+		}
+
+		c.tags = append(c.tags, tags...)
+		c.known = append(c.known, config.Known...)
+		c.unregistered = c.unregistered || config.Unregistered
+	})
+
+	return c.configErr
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	if err := c.loadConfig(); err != nil {
+		return nil, err
+	}
+
+	if len(c.tags) == 0 {
+		return nil, nil
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(node ast.Node) {
+		for _, field := range node.(*ast.StructType).Fields.List {
+			// Tags of the marker field are options of the whole struct: _ struct{} `my_json:"prefix:user_"`
+			if field.Tag == nil || fieldName(field) == "_" {
+				continue
+			}
+
+			structTag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+
+			c.checkTagNames(pass, field, structTag)
+
+			for _, tag := range c.tags {
+				if key, exists := tag.Lookup(reflect.StructTag(structTag)); exists {
+					c.checkField(pass, field, reflect.StructTag(structTag), tag, key)
+				}
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// fieldName returns name of the field (type name for embedded fields)
+func fieldName(field *ast.Field) string {
+	if len(field.Names) != 0 {
+		return field.Names[0].Name
+	}
+
+	typeOf := field.Type
+	if star, ok := typeOf.(*ast.StarExpr); ok {
+		typeOf = star.X
+	}
+
+	switch t := typeOf.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+
+	return ""
+}
+
+func (c *checker) checkField(pass *analysis.Pass, field *ast.Field, structTag reflect.StructTag, tag *tagger.Tag, key string) {
+	fieldTag := tagger.FieldTag{
+		StructTag:     structTag,
+		Name:          key,
+		FieldName:     fieldName(field),
+		TagSymbols:    tag.TagSymbols,
+		CaseSensitive: tag.CaseSensitive,
+		ListKeys:      listKeys(tag),
+	}
+
+	if err := fieldTag.Check(); err != nil {
+		pass.Reportf(field.Tag.Pos(), "%s: malformed tag: %s", tag.Name, err)
+
+		return
+	}
+	fieldTag.Parse()
+
+	c.checkKind(pass, field, tag)
+	for _, name := range tag.Conflicts {
+		if _, exists := structTag.Lookup(name); exists {
+			pass.Reportf(field.Tag.Pos(), "%s: conflicts with the tag %s", tag.Name, name)
+		}
+	}
+
+	// Ignored field: `my_json:"-"`
+	if fieldTag.Raw() == "-" {
+		return
+	}
+
+	duplicated := c.checkKeys(pass, field, fieldTag, tag)
+	for _, option := range tag.Schema {
+		if !fieldTag.Exists(option.Name) {
+			if option.IsRequired {
+				pass.Reportf(field.Tag.Pos(), "%s: required key %q is missing", tag.Name, option.Name)
+			}
+
+			continue
+		}
+
+		if duplicated[normalizeKey(tag, option.Name)] {
+			continue
+		}
+
+		if err := option.Validate(fieldTag); err != nil {
+			pass.Reportf(field.Tag.Pos(), "%s: %s", tag.Name, err)
+		}
+	}
+}
+
+// checkKeys reports unknown and duplicated keys. Returns duplicated keys.
+func (c *checker) checkKeys(pass *analysis.Pass, field *ast.Field, fieldTag tagger.FieldTag, tag *tagger.Tag) map[string]bool {
+	duplicated := make(map[string]bool)
+	seen := make(map[string]bool)
+	parts := fieldTag.Parts()
+	for i, part := range parts {
+		parsed := tagger.FieldTag{
+			StructTag:     reflect.StructTag(part),
+			TagSymbols:    tag.TagSymbols,
+			CaseSensitive: tag.CaseSensitive,
+			ListKeys:      fieldTag.ListKeys,
+		}
+		parsed.Parse()
+		if len(parsed.ParsedTags) == 0 {
+			continue
+		}
+
+		key := parsed.ParsedTags[0][0]
+		originalKey := parsed.OriginalKeys[0]
+		option, declared := findOption(tag, key)
+		if len(tag.Schema) != 0 && !declared && key != "-" {
+			diagnostic := analysis.Diagnostic{
+				Pos:     field.Tag.Pos(),
+				End:     field.Tag.End(),
+				Message: fmt.Sprintf("%s: unknown key %q (available: %s)", tag.Name, originalKey, optionNames(tag)),
+			}
+
+			if suggestion, ok := suggestOption(tag, key); ok {
+				fixed := make([]string, len(parts))
+				copy(fixed, parts)
+				fixed[i] = suggestion + strings.TrimPrefix(part, rawKey(part, tag))
+				diagnostic.SuggestedFixes = c.fix(field, fieldTag.Name, tag, fmt.Sprintf("Replace %q with %q", originalKey, suggestion), fixed)
+			}
+			pass.Report(diagnostic)
+
+			continue
+		}
+
+		if !seen[key] || (declared && option.IsMultiple) {
+			seen[key] = true
+
+			continue
+		}
+
+		duplicated[key] = true
+		fixed := append(append([]string{}, parts[:i]...), parts[i+1:]...)
+		pass.Report(analysis.Diagnostic{
+			Pos:            field.Tag.Pos(),
+			End:            field.Tag.End(),
+			Message:        fmt.Sprintf("%s: duplicated key %q", tag.Name, originalKey),
+			SuggestedFixes: c.fix(field, fieldTag.Name, tag, fmt.Sprintf("Remove %q", part), fixed),
+		})
+	}
+
+	return duplicated
+}
+
+// checkKind reports the tag on a field of unsupported kind
+func (c *checker) checkKind(pass *analysis.Pass, field *ast.Field, tag *tagger.Tag) {
+	if len(tag.Kinds) == 0 {
+		return
+	}
+
+	typeOf := pass.TypesInfo.TypeOf(field.Type)
+	if typeOf == nil {
+		return
+	}
+
+	for {
+		pointer, ok := typeOf.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+
+		typeOf = pointer.Elem()
+	}
+
+	kind, ok := source.KindOf(typeOf)
+	if !ok {
+		return
+	}
+
+	for _, allowed := range tag.Kinds {
+		if allowed == kind {
+			return
+		}
+	}
+
+	names := make([]string, 0, len(tag.Kinds))
+	for _, allowed := range tag.Kinds {
+		names = append(names, allowed.String())
+	}
+
+	pass.Reportf(field.Tag.Pos(), "%s: unsupported kind %s of the field (allowed: %s)", tag.Name, kind, strings.Join(names, ", "))
+}
+
+// fix returns fix which replaces data of the tag (by the key of the struct tags) by the parts
+func (c *checker) fix(field *ast.Field, key string, tag *tagger.Tag, message string, parts []string) []analysis.SuggestedFix {
+	literal, err := replaceTagValue(field.Tag.Value, key, strings.Join(parts, tag.TagSymbols.KeysSeparator))
+	if err != nil {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: message,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     field.Tag.Pos(),
+			End:     field.Tag.End(),
+			NewText: []byte(literal),
+		}},
+	}}
+}
+
+// findOption returns declared option by the normalized key
+func findOption(tag *tagger.Tag, key string) (*tagger.Option, bool) {
+	for _, option := range tag.Schema {
+		if normalizeKey(tag, option.Name) == key {
+			return option, true
+		}
+	}
+
+	return nil, false
+}
+
+// listKeys returns names of the options which values can be lists
+func listKeys(tag *tagger.Tag) (keys []string) {
+	for _, option := range tag.Schema {
+		if option.IsMultiple {
+			keys = append(keys, option.Name)
+		}
+	}
+
+	return
+}
+
+func normalizeKey(tag *tagger.Tag, key string) string {
+	if tag.CaseSensitive {
+		return key
+	}
+
+	return strings.ToLower(key)
+}
+
+func optionNames(tag *tagger.Tag) string {
+	names := make([]string, 0, len(tag.Schema))
+	for _, option := range tag.Schema {
+		names = append(names, option.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// suggestOption returns the closest declared option for an unknown key
+func suggestOption(tag *tagger.Tag, key string) (string, bool) {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	var candidates []candidate
+	for _, option := range tag.Schema {
+		distance := levenshtein(strings.ToLower(key), strings.ToLower(option.Name))
+		if distance <= 2 && distance < len(option.Name) {
+			candidates = append(candidates, candidate{option.Name, distance})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	return candidates[0].name, true
+}
+
+// rawKey returns the key of the part as it's written (with quotes and escapes)
+func rawKey(part string, tag *tagger.Tag) string {
+	if len(tag.TagSymbols.KeyValue) == 0 {
+		return part
+	}
+
+	probe := tagger.FieldTag{
+		StructTag:  reflect.StructTag(part),
+		TagSymbols: tagger.TagSymbols{KeysSeparator: tag.TagSymbols.KeyValue},
+	}
+
+	return probe.Parts()[0]
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,35 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shindakioku/tagger"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analyzer := NewAnalyzer(tagger.New("my_logger").
+		Aliases("logger").
+		Symbols(":", " | ").
+		ForKinds(reflect.String, reflect.Slice, reflect.Struct).
+		ConflictsWith("my_json").
+		Options(
+			tagger.Opt("key").Required(),
+			tagger.Opt("to").OneOf("string", "int"),
+			tagger.Opt("alias").Multiple(),
+			tagger.Opt("limit").Int(),
+		),
+	)
+
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzer, "a")
+}
+
+func TestAnalyzer_Unregistered(t *testing.T) {
+	analyzer := NewAnalyzer(tagger.New("my_logger").Symbols(":", " | "))
+	if err := analyzer.Flags.Set("unregistered", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.Run(t, analysistest.TestData(), analyzer, "b")
+}
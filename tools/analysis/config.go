@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/shindakioku/tagger"
+)
+
+// Config described tags for the analyzer (JSON file passed by -config flag)
+//
+//	{
+//	  "tags": [
+//	    {
+//	      "name": "my_logger",
+//	      "aliases": ["logger"],
+//	      "key_value": ":",
+//	      "keys_separator": " | ",
+//	      "kinds": ["string", "slice", "ptr"],
+//	      "conflicts": ["my_json"],
+//	      "out": true,
+//	      "options": [
+//	        {"name": "key", "required": true},
+//	        {"name": "to", "one_of": ["string", "int"]},
+//	        {"name": "alias", "multiple": true},
+//	        {"name": "limit", "type": "int"}
+//	      ]
+//	    }
+//	  ],
+//	  "known": ["json", "db"],
+//	  "unregistered": true
+//	}
+type Config struct {
+	Tags []TagConfig `json:"tags"`
+	// Names of other tags which aren't reported as misspelled or unregistered
+	Known []string `json:"known"`
+	// Report all tags which aren't registered or known (only misspelled names by default)
+	Unregistered bool `json:"unregistered"`
+}
+
+// TagConfig described tag
+type TagConfig struct {
+	Name          string         `json:"name"`
+	Aliases       []string       `json:"aliases"`
+	KeyValue      string         `json:"key_value"`
+	KeysSeparator string         `json:"keys_separator"`
+	CaseSensitive bool           `json:"case_sensitive"`
+	Kinds         []string       `json:"kinds"`
+	Conflicts     []string       `json:"conflicts"`
+	Options       []OptionConfig `json:"options"`
+	// The tag has handlers for In and Out (used by tagger-inspect)
+	In  bool `json:"in"`
+	Out bool `json:"out"`
+}
+
+// OptionConfig described key of a tag
+type OptionConfig struct {
+	Name string `json:"name"`
+	// string (default), int, bool, duration, regexp
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Multiple bool     `json:"multiple"`
+	OneOf    []string `json:"one_of"`
+}
+
+var optionKinds = map[string]tagger.OptionKind{
+	"":         tagger.StringOption,
+	"string":   tagger.StringOption,
+	"int":      tagger.IntOption,
+	"bool":     tagger.BoolOption,
+	"duration": tagger.DurationOption,
+	"regexp":   tagger.RegexpOption,
+}
+
+// reflectKinds names of reflect.Kind (reflect.String.String() -> reflect.String)
+var reflectKinds = func() map[string]reflect.Kind {
+	kinds := make(map[string]reflect.Kind)
+	for kind := reflect.Bool; kind <= reflect.UnsafePointer; kind++ {
+		kinds[kind.String()] = kind
+	}
+
+	return kinds
+}()
+
+// LoadConfig reads config from the JSON file
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+
+	if err = json.Unmarshal(content, &config); err != nil {
+		return config, errors.New(fmt.Sprintf("Can't parse config %s: %s", path, err))
+	}
+
+	return config, nil
+}
+
+// Build creates tags by the config
+func (c Config) Build() ([]*tagger.Tag, error) {
+	tags := make([]*tagger.Tag, 0, len(c.Tags))
+	for _, tagConfig := range c.Tags {
+		tag, err := tagConfig.Build()
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// Build creates tag by the config
+func (c TagConfig) Build() (*tagger.Tag, error) {
+	tag := tagger.New(c.Name).Aliases(c.Aliases...).Symbols(c.KeyValue, c.KeysSeparator).ConflictsWith(c.Conflicts...)
+	if c.CaseSensitive {
+		tag.CaseSensitiveKeys()
+	}
+
+	for _, name := range c.Kinds {
+		kind, exists := reflectKinds[name]
+		if !exists {
+			return nil, errors.New(fmt.Sprintf("Unknown kind %s of the tag %s", name, c.Name))
+		}
+
+		tag.Kinds = append(tag.Kinds, kind)
+	}
+
+	for _, optionConfig := range c.Options {
+		kind, exists := optionKinds[optionConfig.Type]
+		if !exists {
+			return nil, errors.New(fmt.Sprintf(
+				"Unknown type %s of the option %s (tag %s)", optionConfig.Type, optionConfig.Name, c.Name,
+			))
+		}
+
+		option := tagger.Opt(optionConfig.Name).OneOf(optionConfig.OneOf...)
+		option.Kind = kind
+		option.IsRequired = optionConfig.Required
+		option.IsMultiple = optionConfig.Multiple
+		tag.Schema = append(tag.Schema, option)
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,143 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// replaceTagValue replaces data of the tag by name in the struct tag literal (with quotes).
+// Literal is written with back quotes if it's possible.
+//
+//	`my_json:"a" db:"b"`, my_json, c -> `my_json:"c" db:"b"`
+func replaceTagValue(literal, name, value string) (string, error) {
+	structTag, err := strconv.Unquote(literal)
+	if err != nil {
+		return "", err
+	}
+
+	start, end, ok := lookupSpan(structTag, name)
+	if !ok {
+		return "", errors.New(fmt.Sprintf("tag %s not found", name))
+	}
+
+	structTag = structTag[:start] + strconv.Quote(value) + structTag[end:]
+	if strings.HasPrefix(literal, "`") && strconv.CanBackquote(structTag) {
+		return "`" + structTag + "`", nil
+	}
+
+	return strconv.Quote(structTag), nil
+}
+
+// lookupSpan returns position of the quoted data of the tag by name (the same as reflect.StructTag.Lookup)
+func lookupSpan(tag, name string) (int, int, bool) {
+	offset := 0
+	for tag != "" {
+		// Skip leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		offset += i
+		if tag == "" {
+			break
+		}
+
+		// Name of the tag
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+		offset += i + 1
+
+		// Quoted data of the tag
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+
+		if key == name {
+			return offset, offset + i + 1, true
+		}
+
+		tag = tag[i+1:]
+		offset += i + 1
+	}
+
+	return 0, 0, false
+}
+
+// renameTag replaces name of the tag in the struct tag literal (with quotes)
+//
+//	`my_jsno:"a" db:"b"`, my_jsno, my_json -> `my_json:"a" db:"b"`
+func renameTag(literal, name, newName string) (string, error) {
+	structTag, err := strconv.Unquote(literal)
+	if err != nil {
+		return "", err
+	}
+
+	start, _, ok := lookupSpan(structTag, name)
+	if !ok {
+		return "", errors.New(fmt.Sprintf("tag %s not found", name))
+	}
+
+	// start is the position of the quoted data after "name:"
+	nameStart := start - len(name) - 1
+	structTag = structTag[:nameStart] + newName + structTag[start-1:]
+	if strings.HasPrefix(literal, "`") && strconv.CanBackquote(structTag) {
+		return "`" + structTag + "`", nil
+	}
+
+	return strconv.Quote(structTag), nil
+}
+
+// tagNames returns names of the tags in the struct tag (the same as reflect.StructTag.Lookup sees them)
+func tagNames(structTag string) (names []string) {
+	for tag := structTag; tag != ""; {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		names = append(names, tag[:i])
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		tag = tag[i+1:]
+	}
+
+	return
+}
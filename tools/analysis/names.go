@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// knownTags tags of the standard library and popular packages which aren't reported as misspelled
+var knownTags = []string{
+	"asn1", "binding", "bson", "csv", "db", "default", "env", "flag", "form", "gorm", "header", "json",
+	"mapstructure", "msgpack", "protobuf", "query", "schema", "toml", "uri", "validate", "xml", "yaml",
+}
+
+// checkTagNames reports tags of the field which aren't registered: misspelled names of the registered tags always,
+// other names only with the -unregistered flag
+func (c *checker) checkTagNames(pass *analysis.Pass, field *ast.Field, structTag string) {
+	registered := c.registeredNames()
+	for _, name := range tagNames(structTag) {
+		if containsString(registered, name) || containsString(c.known, name) {
+			continue
+		}
+
+		if suggestion, ok := suggestTag(registered, name); ok {
+			diagnostic := analysis.Diagnostic{
+				Pos:     field.Tag.Pos(),
+				End:     field.Tag.End(),
+				Message: fmt.Sprintf("unknown tag %q (did you mean %q?)", name, suggestion),
+			}
+
+			if literal, err := renameTag(field.Tag.Value, name, suggestion); err == nil {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+					Message:   fmt.Sprintf("Replace %q with %q", name, suggestion),
+					TextEdits: []analysis.TextEdit{{Pos: field.Tag.Pos(), End: field.Tag.End(), NewText: []byte(literal)}},
+				}}
+			}
+			pass.Report(diagnostic)
+
+			continue
+		}
+
+		if c.unregistered && !containsString(knownTags, name) {
+			pass.Reportf(field.Tag.Pos(), "tag %q isn't registered (registered: %s)", name, strings.Join(registered, ", "))
+		}
+	}
+}
+
+// registeredNames returns names and aliases of the tags
+func (c *checker) registeredNames() []string {
+	var names []string
+	for _, tag := range c.tags {
+		for _, key := range tag.Keys() {
+			if !containsString(names, key) {
+				names = append(names, key)
+			}
+		}
+	}
+
+	return names
+}
+
+// suggestTag returns the closest registered name for a misspelled one: "my_jsno" -> "my_json".
+// Short names must be closer, so "db" isn't a misspelling of "pb".
+func suggestTag(registered []string, name string) (string, bool) {
+	if containsString(knownTags, name) {
+		return "", false
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	var candidates []candidate
+	for _, key := range registered {
+		limit := 2
+		if len(key) < 5 {
+			limit = 1
+		}
+
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(key))
+		if strings.EqualFold(name, key) || (distance <= limit && distance < len(key)) {
+			candidates = append(candidates, candidate{key, distance})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	return candidates[0].name, true
+}
+
+func containsString(set []string, need string) bool {
+	for _, v := range set {
+		if v == need {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,27 @@
+package a
+
+type Profile struct {
+	Email string `my_logger:"key:email"`
+}
+
+type Logger struct {
+	_          struct{} `my_logger:"prefix:log_"`
+	Summary    []byte   `my_logger:"key:summary | to:string"`
+	Typo       string   `my_logger:"kye:typo"`                 // want `my_logger: unknown key "kye" \(available: key, to, alias, limit\)` `my_logger: required key "key" is missing`
+	Repeated   string   `my_logger:"key:repeated | key:again"` // want `my_logger: duplicated key "key"`
+	Aliases    string   `my_logger:"key:aliases | alias:[a, b] | alias:c"`
+	Missing    string   `my_logger:"to:string"`                       // want `my_logger: required key "key" is missing`
+	Invalid    string   `my_logger:"key:invalid | to:bytes"`          // want `my_logger: Incorrect value of the key: to \(tag: my_logger, field: Invalid\). Expected one of: string, int. Actual: bytes`
+	Limit      string   `my_logger:"key:limit | limit:ten"`           // want `my_logger: Incorrect value of the key: limit \(tag: my_logger, field: Limit\). Expected integer. Actual: ten`
+	Quote      string   `my_logger:"key:'quote"`                      // want `my_logger: malformed tag: Unterminated quote '`
+	Separator  string   `my_logger:"key:separator |  | to:int"`       // want `my_logger: malformed tag: Empty part of the tag \(repeated separator " \| "\)`
+	Channel    chan int `my_logger:"key:channel"`                     // want `my_logger: unsupported kind chan of the field \(allowed: string, slice, struct\)`
+	Conflict   string   `my_logger:"key:conflict" my_json:"conflict"` // want `my_logger: conflicts with the tag my_json`
+	Profile    *Profile `my_logger:"key:profile" db:"profile"`
+	Ignored    string   `my_logger:"-"`
+	Other      string   `db:"kye"`
+	Alias      string   `logger:"kye:alias"`                        // want `my_logger: unknown key "kye" \(available: key, to, alias, limit\)` `my_logger: required key "key" is missing`
+	Misspelled string   `my_loger:"key:misspelled"`                 // want `unknown tag "my_loger" \(did you mean "my_logger"\?\)`
+	Upper      string   `MY_LOGGER:"key:upper"`                     // want `unknown tag "MY_LOGGER" \(did you mean "my_logger"\?\)`
+	Short      string   `loger:"key:short" db:"short" json:"short"` // want `unknown tag "loger" \(did you mean "logger"\?\)`
+}
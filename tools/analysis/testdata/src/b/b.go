@@ -0,0 +1,8 @@
+package b
+
+type User struct {
+	ID      uint   `my_logger:"key:id" json:"id" db:"id"`
+	Name    string `my_logger:"key:name" yaml_ext:"name"` // want `tag "yaml_ext" isn't registered \(registered: my_logger\)`
+	Email   string `my_loger:"key:email"`                 // want `unknown tag "my_loger" \(did you mean "my_logger"\?\)`
+	Comment string `comment:"note"`                       // want `tag "comment" isn't registered \(registered: my_logger\)`
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/shindakioku/tagger/tools/internal/source"
+)
+
+// Generator generates functions of the structs which have the tags
+type Generator struct {
+	// Tag names
+	Tags []string
+	// Type names. All structs with the tags if it's empty
+	Types []string
+	// Path of the output file (it's skipped while parsing)
+	Output string
+}
+
+// structType described struct for the template
+type structType struct {
+	Name   string
+	Fields []structField
+}
+
+// structField described field of a struct for the template
+type structField struct {
+	Index int
+	Name  string
+	// Pointer field is allocated by In
+	IsPtr bool
+	// Type of the pointer's element for allocation. Empty if it can't be named in the package
+	ElemType string
+}
+
+// Generate returns formatted source of the generated file for a package in the dir
+func (g Generator) Generate(dir string) ([]byte, error) {
+	typesPkg, err := source.Load(dir, g.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]string{"github.com/shindakioku/tagger": "tagger"}
+	qualifier := func(other *types.Package) string {
+		if other == typesPkg {
+			return ""
+		}
+
+		imports[other.Path()] = other.Name()
+
+		return other.Name()
+	}
+
+	var structs []structType
+	scope := typesPkg.Scope()
+	for _, name := range scope.Names() {
+		if len(g.Types) != 0 && !containsString(g.Types, name) {
+			continue
+		}
+
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() != 0 {
+			continue
+		}
+
+		structOf, ok := named.Underlying().(*types.Struct)
+		if !ok || !g.hasTags(structOf) {
+			continue
+		}
+
+		structs = append(structs, g.structType(name, structOf, typesPkg, qualifier))
+	}
+
+	if len(structs) == 0 {
+		return nil, errors.New(fmt.Sprintf("no structs with tags %s in %s", strings.Join(g.Tags, ", "), dir))
+	}
+
+	// Standard packages first
+	var paths [2][]string
+	for path := range imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			paths[1] = append(paths[1], path)
+		} else {
+			paths[0] = append(paths[0], path)
+		}
+	}
+	sort.Strings(paths[0])
+	sort.Strings(paths[1])
+
+	var buffer bytes.Buffer
+	err = fileTemplate.Execute(&buffer, map[string]any{
+		"Package": typesPkg.Name(),
+		"Imports": paths,
+		"Structs": structs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("can't format generated code: %s", err))
+	}
+
+	return source, nil
+}
+
+// hasTags does any field of the struct have any of the tags
+func (g Generator) hasTags(structOf *types.Struct) bool {
+	for i := 0; i < structOf.NumFields(); i++ {
+		for _, tag := range g.Tags {
+			if _, exists := reflect.StructTag(structOf.Tag(i)).Lookup(tag); exists {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (g Generator) structType(name string, structOf *types.Struct, pkg *types.Package, qualifier types.Qualifier) structType {
+	output := structType{Name: name}
+	for i := 0; i < structOf.NumFields(); i++ {
+		variable := structOf.Field(i)
+		// Unexported fields aren't processed by handlers
+		if !variable.Exported() {
+			continue
+		}
+
+		field := structField{Index: i, Name: variable.Name()}
+		if pointer, ok := variable.Type().Underlying().(*types.Pointer); ok {
+			field.IsPtr = true
+			if canBeNamed(pointer.Elem(), pkg) {
+				field.ElemType = types.TypeString(pointer.Elem(), qualifier)
+			}
+		}
+
+		output.Fields = append(output.Fields, field)
+	}
+
+	return output
+}
+
+// HasNamedPointers does the struct have pointer fields which are allocated by their types
+func (s structType) HasNamedPointers() bool {
+	for _, field := range s.Fields {
+		if field.IsPtr && len(field.ElemType) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canBeNamed can the type be written in the package (unexported types of other packages can't)
+func canBeNamed(typeOf types.Type, pkg *types.Package) bool {
+	switch t := typeOf.(type) {
+	case *types.Named:
+		object := t.Obj()
+		if object.Pkg() != nil && object.Pkg() != pkg && !object.Exported() {
+			return false
+		}
+
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			if !canBeNamed(t.TypeArgs().At(i), pkg) {
+				return false
+			}
+		}
+
+		return true
+	case *types.Pointer:
+		return canBeNamed(t.Elem(), pkg)
+	case *types.Slice:
+		return canBeNamed(t.Elem(), pkg)
+	case *types.Array:
+		return canBeNamed(t.Elem(), pkg)
+	case *types.Map:
+		return canBeNamed(t.Key(), pkg) && canBeNamed(t.Elem(), pkg)
+	case *types.Chan:
+		return canBeNamed(t.Elem(), pkg)
+	case *types.Basic:
+		return true
+	}
+
+	return false
+}
+
+func containsString(set []string, need string) bool {
+	for _, v := range set {
+		if v == need {
+			return true
+		}
+	}
+
+	return false
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by tagger-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range index .Imports 0 }}
+	"{{ . }}"
+{{- end }}
+{{ range index .Imports 1 }}
+	"{{ . }}"
+{{- end }}
+)
+
+func init() {
+{{- range .Structs }}
+	tagger.RegisterGenerated(taggerType{{ .Name }}, taggerIn{{ .Name }}, taggerOut{{ .Name }})
+{{- end }}
+}
+{{ range .Structs }}
+var taggerType{{ .Name }} = tagger.NewGeneratedType((*{{ .Name }})(nil))
+
+// taggerIn{{ .Name }} fills fields of {{ .Name }} by the handlers of the tags
+func taggerIn{{ .Name }}(s *tagger.GeneratedStruct, data any, value any) (err error) {
+{{- if .HasNamedPointers }}
+	in := value.(*{{ .Name }})
+{{- end }}
+{{- range .Fields }}{{ if .IsPtr }}
+{{- if .ElemType }}
+	in.{{ .Name }} = new({{ .ElemType }})
+{{- else }}
+	s.Allocate({{ .Index }})
+{{- end }}
+{{- end }}{{ end }}
+{{ range .Fields }}
+	if err = s.In(data, {{ .Index }}); err != nil {
+		return
+	}
+{{- end }}
+
+	return
+}
+
+// taggerOut{{ .Name }} exports fields of {{ .Name }} by the handlers of the tags
+func taggerOut{{ .Name }}(s *tagger.GeneratedStruct, data any, _ any) (output any, err error) {
+	output = data
+{{ range .Fields }}
+	if output, err = s.Out(output, {{ .Index }}); err != nil {
+		return
+	}
+{{- end }}
+
+	return
+}
+{{ end }}`))
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const generatorTestSource = `package models
+
+import "time"
+
+type Profile struct {
+	Email *string
+}
+
+type secret struct {
+	Token string
+}
+
+type User struct {
+	ID        uint       ` + "`my_json:\"user_id\"`" + `
+	Profile   Profile    ` + "`my_json:\"profile\"`" + `
+	Profile2  *Profile   ` + "`my_json:\"profile2\"`" + `
+	CreatedAt *time.Time
+	internal  string
+	Secret    *secret
+}
+
+type Ignored struct {
+	Name string ` + "`db:\"name\"`" + `
+}
+`
+
+func TestGenerator_Generate(t *testing.T) {
+	cases := []struct {
+		name      string
+		generator Generator
+		expect    func(source string, err error) bool
+	}{
+		{
+			name:      "Test structs with the tags",
+			generator: Generator{Tags: []string{"my_json"}},
+			expect: func(source string, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Contains(t, source, "// Code generated by tagger-gen. DO NOT EDIT.") &&
+					assert.Contains(t, source, "package models") &&
+					assert.Contains(t, source, "\t\"time\"\n\n\t\"github.com/shindakioku/tagger\"") &&
+					assert.NotContains(t, source, "\"reflect\"") &&
+					assert.Contains(t, source, "tagger.RegisterGenerated(taggerTypeUser, taggerInUser, taggerOutUser)") &&
+					assert.Contains(t, source, "func taggerInUser(s *tagger.GeneratedStruct, data any, value any) (err error) {") &&
+					assert.Contains(t, source, "in.Profile2 = new(Profile)") &&
+					assert.Contains(t, source, "in.CreatedAt = new(time.Time)") &&
+					assert.Contains(t, source, "in.Secret = new(secret)") &&
+					assert.Contains(t, source, "if err = s.In(data, 3); err != nil {") &&
+					assert.Contains(t, source, "if output, err = s.Out(output, 5); err != nil {") &&
+					assert.NotContains(t, source, "s.In(data, 4)") &&
+					assert.NotContains(t, source, "taggerTypeProfile") &&
+					assert.NotContains(t, source, "taggerTypeIgnored")
+			},
+		},
+		{
+			name:      "Test selected types",
+			generator: Generator{Tags: []string{"my_json", "db"}, Types: []string{"Ignored"}},
+			expect: func(source string, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Contains(t, source, "taggerTypeIgnored") &&
+					assert.NotContains(t, source, "taggerTypeUser")
+			},
+		},
+		{
+			name:      "Test without structs",
+			generator: Generator{Tags: []string{"yaml"}},
+			expect: func(source string, err error) bool {
+				return assert.NotNil(t, err)
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(generatorTestSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cases {
+		c.generator.Output = filepath.Join(dir, "tagger_gen.go")
+		source, err := c.generator.Generate(dir)
+		if !c.expect(string(source), err) {
+			t.Error(fmt.Sprintf("[TestGenerator_Generate] %s is not true", c.name))
+		}
+	}
+}
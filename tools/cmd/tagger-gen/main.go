@@ -0,0 +1,76 @@
+// Command tagger-gen generates In/Out functions of structs which pass the fields to the handlers of the tags
+// without walking over the structs by reflection: field tags are parsed and handlers are found only once.
+// Structs which have at least one of the passed tags are generated.
+// Taggers use generated functions automatically (the tagger is still the same Tagger), so nothing else must be changed.
+// Handlers still get fields as reflect.Value (Field.Value), so every processed field is taken by reflection:
+// only the walk over the struct, the parsing of the field tags and the lookup of the handlers are saved.
+//
+//	//go:generate go run github.com/shindakioku/tagger/tools/cmd/tagger-gen -tags my_json,my_logger
+//
+// Usage:
+//
+//	tagger-gen -tags my_json[,my_logger] [-types User,Profile] [-output tagger_gen.go] [dir]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("tagger-gen: ")
+
+	tags := flag.String("tags", "", "comma-separated list of tag names; required")
+	types := flag.String("types", "", "comma-separated list of type names; all structs with the tags if it's empty")
+	output := flag.String("output", "tagger_gen.go", "output file name")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: tagger-gen -tags my_json[,my_logger] [-types User] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if len(*tags) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	outputPath := *output
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(dir, outputPath)
+	}
+
+	generator := Generator{
+		Tags:   splitList(*tags),
+		Types:  splitList(*types),
+		Output: outputPath,
+	}
+
+	source, err := generator.Generate(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = os.WriteFile(outputPath, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func splitList(value string) (output []string) {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); len(v) != 0 {
+			output = append(output, v)
+		}
+	}
+
+	return
+}
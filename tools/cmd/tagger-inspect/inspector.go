@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/tools/analysis"
+	"github.com/shindakioku/tagger/tools/internal/source"
+)
+
+// Inspector explains structs from the source code the same way as tagger.Explain does it by reflection
+type Inspector struct {
+	// Described tags by name
+	tags    map[string]*tagger.Tag
+	configs map[string]analysis.TagConfig
+	// Names of the tags in order of the config (order of registration)
+	names []string
+	// Tags for process
+	tagsForWork map[string]bool
+	tagForEmpty string
+}
+
+// NewInspector creates an inspector for the described tags
+func NewInspector(config analysis.Config, tagForEmpty string, tagsForProcess []string) (*Inspector, error) {
+	inspector := &Inspector{
+		tags:        make(map[string]*tagger.Tag),
+		configs:     make(map[string]analysis.TagConfig),
+		tagsForWork: make(map[string]bool),
+		tagForEmpty: tagForEmpty,
+	}
+
+	for _, tagConfig := range config.Tags {
+		tag, err := tagConfig.Build()
+		if err != nil {
+			return nil, err
+		}
+
+		inspector.tags[tag.Name] = tag
+		inspector.configs[tag.Name] = tagConfig
+		inspector.names = append(inspector.names, tag.Name)
+		if len(tagsForProcess) == 0 {
+			inspector.tagsForWork[tag.Name] = true
+		}
+	}
+
+	for _, name := range tagsForProcess {
+		if _, exists := inspector.tags[name]; !exists {
+			return nil, errors.New(fmt.Sprintf("%s tag doesn't exists. Check provided arguments please.", name))
+		}
+
+		inspector.tagsForWork[name] = true
+	}
+
+	if len(tagForEmpty) != 0 && !inspector.tagsForWork[tagForEmpty] {
+		return nil, errors.New(fmt.Sprintf("%s doesn't exists (empty field parser)", tagForEmpty))
+	}
+
+	return inspector, nil
+}
+
+// Explain returns explanation of the struct by name from the package
+func (i *Inspector) Explain(pkg *types.Package, name string) (tagger.Explanation, error) {
+	explanation := tagger.Explanation{}
+	typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return explanation, errors.New(fmt.Sprintf("type %s not found in %s", name, pkg.Name()))
+	}
+
+	structOf, ok := typeName.Type().Underlying().(*types.Struct)
+	if !ok {
+		return explanation, errors.New(fmt.Sprintf("%s must be a structure", name))
+	}
+
+	explanation.Type = pkg.Name() + "." + name
+	explanation.Fields = i.explainFields(structOf, "", map[*types.Struct]bool{structOf: true})
+
+	return explanation, nil
+}
+
+func (i *Inspector) explainFields(structOf *types.Struct, path string, parents map[*types.Struct]bool) (fields []tagger.ExplainedField) {
+	for index := 0; index < structOf.NumFields(); index++ {
+		variable := structOf.Field(index)
+		// Unexported fields aren't processed
+		if !variable.Exported() {
+			continue
+		}
+		structTag := reflect.StructTag(structOf.Tag(index))
+		explained := tagger.ExplainedField{
+			Path: path + variable.Name(),
+			Type: types.TypeString(variable.Type(), func(other *types.Package) string { return other.Name() }),
+		}
+
+		if kind, ok := source.KindOf(variable.Type()); ok {
+			explained.Kind = kind.String()
+		}
+
+		for _, name := range i.names {
+			if explainedTag, exists := i.tags[name].Explain(structTag, variable.Name()); exists {
+				explained.Tags = append(explained.Tags, explainedTag)
+			}
+		}
+
+		for _, name := range i.handlers(structTag) {
+			if i.configs[name].In {
+				explained.In = append(explained.In, name)
+			}
+
+			if i.configs[name].Out {
+				explained.Out = append(explained.Out, name)
+			}
+		}
+
+		nestedType := variable.Type()
+		if pointer, ok := nestedType.Underlying().(*types.Pointer); ok {
+			nestedType = pointer.Elem()
+		}
+
+		// Recursive structs are explained only once
+		if nested, ok := nestedType.Underlying().(*types.Struct); ok && !parents[nested] {
+			parents[nested] = true
+			explained.Fields = i.explainFields(nested, explained.Path+".", parents)
+			delete(parents, nested)
+		}
+
+		fields = append(fields, explained)
+	}
+
+	return
+}
+
+// handlers returns names of the tags which handlers run for the field
+func (i *Inspector) handlers(structTag reflect.StructTag) (names []string) {
+	if len(structTag) == 0 && len(i.tagForEmpty) != 0 {
+		return []string{i.tagForEmpty}
+	}
+
+	for _, name := range i.names {
+		if _, exists := i.tags[name].Lookup(structTag); exists && i.tagsForWork[name] {
+			names = append(names, name)
+		}
+	}
+
+	return
+}
+
+func splitList(value string) (output []string) {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); len(v) != 0 {
+			output = append(output, v)
+		}
+	}
+
+	return
+}
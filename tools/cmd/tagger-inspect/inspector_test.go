@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shindakioku/tagger"
+	"github.com/shindakioku/tagger/tools/analysis"
+	"github.com/shindakioku/tagger/tools/internal/source"
+)
+
+const inspectorTestSource = `package models
+
+type Profile struct {
+	Email string ` + "`my_json:\"email\"`" + `
+}
+
+type User struct {
+	ID      int      ` + "`my_json:\"user_id\" my_logger:\"key:id\"`" + `
+	Profile *Profile ` + "`my_json:\"profile\"`" + `
+	Comment string
+}
+`
+
+func TestInspector_Explain(t *testing.T) {
+	config := analysis.Config{Tags: []analysis.TagConfig{
+		{Name: "my_json", In: true, Out: true},
+		{Name: "my_logger", KeyValue: ":", KeysSeparator: " | ", Out: true},
+	}}
+
+	cases := []struct {
+		name        string
+		tagForEmpty string
+		typeName    string
+		expect      func(explanation tagger.Explanation, err error) bool
+	}{
+		{
+			name:     "Test struct with nested struct",
+			typeName: "User",
+			expect: func(explanation tagger.Explanation, err error) bool {
+				return assert.Nil(t, err) && assert.Equal(t, "models.User\n"+
+					"  ID int (int)\n"+
+					"    my_json \"user_id\" [user_id=user_id]\n"+
+					"    my_logger \"key:id\" [key=id]\n"+
+					"    in: my_json\n"+
+					"    out: my_json, my_logger\n"+
+					"  Profile *models.Profile (ptr)\n"+
+					"    my_json \"profile\" [profile=profile]\n"+
+					"    in: my_json\n"+
+					"    out: my_json\n"+
+					"    Profile.Email string (string)\n"+
+					"      my_json \"email\" [email=email]\n"+
+					"      in: my_json\n"+
+					"      out: my_json\n"+
+					"  Comment string (string)\n", explanation.String())
+			},
+		},
+		{
+			name:        "Test tag for empty",
+			tagForEmpty: "my_logger",
+			typeName:    "User",
+			expect: func(explanation tagger.Explanation, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, []string{"my_logger"}, explanation.Fields[2].Out)
+			},
+		},
+		{
+			name:     "Test unknown type",
+			typeName: "Order",
+			expect: func(explanation tagger.Explanation, err error) bool {
+				return assert.NotNil(t, err)
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(inspectorTestSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, err := source.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cases {
+		inspector, err := NewInspector(config, c.tagForEmpty, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !c.expect(inspector.Explain(pkg, c.typeName)) {
+			t.Error(fmt.Sprintf("[TestInspector_Explain] %s is not true", c.name))
+		}
+	}
+}
+
+func TestInspector_ExplainOrder(t *testing.T) {
+	config := analysis.Config{Tags: []analysis.TagConfig{
+		{Name: "my_logger", KeyValue: ":", KeysSeparator: " | ", Out: true},
+		{Name: "my_json", In: true, Out: true},
+	}}
+
+	cases := []struct {
+		name   string
+		expect func(explanation tagger.Explanation, err error) bool
+	}{
+		{
+			name: "Test tags and handlers in order of the config",
+			expect: func(explanation tagger.Explanation, err error) bool {
+				return assert.Nil(t, err) &&
+					assert.Equal(t, "my_logger", explanation.Fields[0].Tags[0].Name) &&
+					assert.Equal(t, "my_json", explanation.Fields[0].Tags[1].Name) &&
+					assert.Equal(t, []string{"my_logger", "my_json"}, explanation.Fields[0].Out)
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(inspectorTestSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, err := source.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inspector, err := NewInspector(config, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cases {
+		if !c.expect(inspector.Explain(pkg, "User")) {
+			t.Error(fmt.Sprintf("[TestInspector_ExplainOrder] %s is not true", c.name))
+		}
+	}
+}
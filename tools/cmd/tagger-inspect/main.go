@@ -0,0 +1,72 @@
+// Command tagger-inspect prints how a tagger sees a struct: fields, matched tags,
+// parsed keys by symbols of the tags and tags which handlers run for In and Out.
+// Tags are described by the same JSON config as for tagger-vet (see analysis.Config).
+//
+// Usage:
+//
+//	tagger-inspect -config tags.json [-json] [-empty my_json] [-tags my_json,my_logger] [-dir .] User
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shindakioku/tagger/tools/analysis"
+	"github.com/shindakioku/tagger/tools/internal/source"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("tagger-inspect: ")
+
+	configPath := flag.String("config", "", "path to the JSON file with described tags; required")
+	asJSON := flag.Bool("json", false, "print as JSON")
+	tagForEmpty := flag.String("empty", "", "tag for fields which not have tags")
+	tags := flag.String("tags", "", "comma-separated list of tags for process; all tags if it's empty")
+	dir := flag.String("dir", ".", "directory of the package")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: tagger-inspect -config tags.json [-json] [-empty tag] [-tags a,b] [-dir .] Type\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if len(*configPath) == 0 || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config, err := analysis.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pkg, err := source.Load(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	inspector, err := NewInspector(config, *tagForEmpty, splitList(*tags))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	explanation, err := inspector.Explain(pkg, flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(explanation)
+	} else {
+		err = explanation.WriteText(os.Stdout)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
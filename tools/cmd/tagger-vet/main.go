@@ -0,0 +1,13 @@
+// Command tagger-vet checks struct tags of the tagger tags described by the JSON config.
+//
+//	go vet -vettool=$(which tagger-vet) -taggerlint.config=tags.json ./...
+package main
+
+import (
+	"github.com/shindakioku/tagger/tools/analysis"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(analysis.Analyzer)
+}
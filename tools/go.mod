@@ -0,0 +1,19 @@
+module github.com/shindakioku/tagger/tools
+
+go 1.22.0
+
+require (
+	github.com/shindakioku/tagger v0.0.0
+	github.com/stretchr/testify v1.7.1
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
+
+replace github.com/shindakioku/tagger => ../
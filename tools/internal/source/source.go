@@ -0,0 +1,137 @@
+// Package source loads and type-checks Go packages for the commands.
+package source
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Load parses and type-checks the package in the dir.
+// Tests, files excluded by build tags and skipped files (for example: generated output) aren't loaded.
+// Type errors are ignored, so the package can be incomplete.
+func Load(dir string, skip ...string) (*types.Package, error) {
+	fileSet := token.NewFileSet()
+	name, files, err := parse(fileSet, dir, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	config := types.Config{
+		Importer: importer.ForCompiler(fileSet, "source", nil),
+		Error:    func(error) {},
+	}
+	pkg, _ := config.Check(name, fileSet, files, nil)
+
+	return pkg, nil
+}
+
+// parse returns name and files of the package
+func parse(fileSet *token.FileSet, dir string, skip []string) (string, []*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	skipped := make(map[string]bool, len(skip))
+	for _, path := range skip {
+		absolute, _ := filepath.Abs(path)
+		skipped[absolute] = true
+	}
+
+	var (
+		pkg   string
+		files []*ast.File
+	)
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if absolute, _ := filepath.Abs(path); skipped[absolute] {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, path, nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if len(pkg) != 0 && pkg != file.Name.Name {
+			return "", nil, errors.New(fmt.Sprintf("found packages %s and %s in %s", pkg, file.Name.Name, dir))
+		}
+
+		pkg = file.Name.Name
+		files = append(files, file)
+	}
+
+	if len(files) == 0 {
+		return "", nil, errors.New(fmt.Sprintf("no go files in %s", dir))
+	}
+
+	return pkg, files, nil
+}
+
+// KindOf returns reflect.Kind of the type
+func KindOf(typeOf types.Type) (reflect.Kind, bool) {
+	switch t := typeOf.Underlying().(type) {
+	case *types.Basic:
+		kind, ok := basicKinds[t.Kind()]
+
+		return kind, ok
+	case *types.Struct:
+		return reflect.Struct, true
+	case *types.Slice:
+		return reflect.Slice, true
+	case *types.Array:
+		return reflect.Array, true
+	case *types.Map:
+		return reflect.Map, true
+	case *types.Chan:
+		return reflect.Chan, true
+	case *types.Signature:
+		return reflect.Func, true
+	case *types.Interface:
+		return reflect.Interface, true
+	case *types.Pointer:
+		return reflect.Ptr, true
+	}
+
+	return reflect.Invalid, false
+}
+
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}